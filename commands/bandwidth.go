@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/control"
+)
+
+var (
+	bandwidthTarget string
+)
+
+// BandwidthCmd will change the bandwidth limit of a running task.
+var BandwidthCmd = &cobra.Command{
+	Use:   "bandwidth [task name] [bytes per second]",
+	Short: "Change the bandwidth limit of a running task, 0 means no limit",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		n, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || n < 0 {
+			logrus.Errorf("%s is not a valid bandwidth limit.", args[1])
+			return
+		}
+
+		err = sendControl(cmd.Flag("config").Value.String(), &control.Request{
+			Task:    args[0],
+			Command: constants.ControlCommandBandwidth,
+			Target:  bandwidthTarget,
+			Value:   n,
+		})
+		if err != nil {
+			logrus.Errorf("Set bandwidth limit failed for %v.", err)
+			return
+		}
+		logrus.Infof("Bandwidth limit of task %s %s has been set to %d.", args[0], bandwidthTarget, n)
+	},
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// exporter will write items of a task in csv or jsonl format.
+type exporter struct {
+	// header is the first line of csv.
+	header []string
+	// row will format an item into a csv line.
+	row func(v interface{}) []string
+	// next will return the next item, nil means no more items.
+	next func(ctx context.Context) (v interface{}, err error)
+}
+
+// run will load task name and export its items into output, stdout will be
+// used if output is empty.
+func (e *exporter) run(name, output, format string) (err error) {
+	ctx := context.Background()
+	// Load and check task.
+	t, err := model.GetTaskByName(ctx, name)
+	if err != nil {
+		logrus.Panicf("Task load failed for %v.", err)
+		return
+	}
+	if t == nil {
+		logrus.Errorf("Task %s is not exist.", name)
+		return
+	}
+
+	ctx = utils.NewTaskContext(ctx, t.Name)
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			logrus.Errorf("Create file %s failed for %v.", output, err)
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return e.write(ctx, w, format)
+}
+
+// write will write all items returned by next into w.
+func (e *exporter) write(ctx context.Context, w io.Writer, format string) (err error) {
+	var fn func(v interface{}) error
+	flush := func() error { return nil }
+
+	switch format {
+	case constants.ReportFormatCSV:
+		cw := csv.NewWriter(w)
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+
+		err = cw.Write(e.header)
+		if err != nil {
+			return
+		}
+		fn = func(v interface{}) error {
+			return cw.Write(e.row(v))
+		}
+	case constants.ReportFormatJSONL:
+		enc := json.NewEncoder(w)
+		fn = func(v interface{}) error {
+			return enc.Encode(v)
+		}
+	default:
+		logrus.Errorf("Export format %s is not supported.", format)
+		return constants.ErrTaskInvalid
+	}
+
+	for {
+		v, err := e.next(ctx)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			break
+		}
+
+		err = fn(v)
+		if err != nil {
+			return err
+		}
+	}
+	return flush()
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/yunify/qscamel/model"
+)
+
+var (
+	failureFormat string
+	failureOutput string
+)
+
+// FailuresCmd will provide failures command for qscamel.
+var FailuresCmd = &cobra.Command{
+	Use:   "failures [task name]",
+	Short: "Export the failed objects of a task",
+	Args:  cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		return initContext(cmd.Flag("config").Value.String())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := newFailureExporter().run(args[0], failureOutput, failureFormat)
+		if err != nil {
+			logrus.Errorf("Export failures failed for %v.", err)
+		}
+	},
+	PostRunE: func(cmd *cobra.Command, args []string) error {
+		return cleanUp()
+	},
+}
+
+// newFailureExporter will create an exporter for all failures of a task.
+func newFailureExporter() *exporter {
+	p := ""
+	// Objects failed to be deleted from destination by sync task are
+	// stored with the destination context.
+	dst := false
+	return &exporter{
+		header: []string{"key", "phase", "error", "retries", "timestamp"},
+		row: func(v interface{}) []string {
+			f := v.(*model.Failure)
+			return []string{
+				f.Key, f.Phase, f.Error,
+				strconv.Itoa(f.Retries), time.Unix(f.Timestamp, 0).Format(time.RFC3339),
+			}
+		},
+		next: func(ctx context.Context) (interface{}, error) {
+			for {
+				c := ctx
+				if dst {
+					c = model.NewDestinationContext(ctx)
+				}
+				f, err := model.NextFailure(c, p)
+				if err != nil {
+					return nil, err
+				}
+				if f != nil {
+					p = f.Key
+					return f, nil
+				}
+				if dst {
+					return nil, nil
+				}
+				dst, p = true, ""
+			}
+		},
+	}
+}
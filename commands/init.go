@@ -1,43 +1,65 @@
 package commands
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
 	"github.com/pengsrc/go-shared/pid"
 	"github.com/sirupsen/logrus"
 
 	"github.com/yunify/qscamel/config"
+	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/control"
+	"github.com/yunify/qscamel/migrate"
 )
 
+// pidFile is the PID file of current qscamel.
+var pidFile *pid.File
+
+// ExitCode is the code qscamel should exit with after command executed.
+var ExitCode int
+
 func init() {
 	RunCmd.Flags().StringVarP(&taskPath, "task", "t", "", "task path")
+	ReportCmd.Flags().StringVarP(&reportFormat, "format", "f", constants.ReportFormatCSV, "report format, csv or jsonl")
+	ReportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "report output path, default to stdout")
+	FailuresCmd.Flags().StringVarP(&failureFormat, "format", "f", constants.ReportFormatCSV, "failure format, csv or jsonl")
+	FailuresCmd.Flags().StringVarP(&failureOutput, "output", "o", "", "failure output path, default to stdout")
+	BandwidthCmd.Flags().StringVarP(&bandwidthTarget, "target", "t", constants.BandwidthTargetTask, "bandwidth limit target, task, source or destination")
+	StatusCmd.Flags().StringVarP(&statusOutput, "output", "o", constants.StatusOutputTable, "status output format, json, yaml or table")
 }
 
 func initContext(configFile string) error {
-	c := &config.Config{}
-	if err := c.LoadFromFilePath(configFile); err != nil {
-		logrus.Errorf("Load config from %s failed for %v.", configFile, err)
-		return err
-	}
+	return setupContext(configFile, false)
+}
 
-	// Check config.
-	if err := c.Check(); err != nil {
-		logrus.Errorf("Config check failed for %v.", err)
+// initPlanContext will setup contexts with an in memory database so that
+// nothing will be written to the database file.
+func initPlanContext(configFile string) error {
+	return setupContext(configFile, true)
+}
+
+func setupContext(configFile string, inMemory bool) error {
+	c, err := loadConfig(configFile)
+	if err != nil {
 		return err
 	}
+	c.InMemoryDatabase = inMemory
 
-	// Create PID file.
-	if pidfile := c.PIDFile; pidfile != "" {
-		p, err := pid.New(pidfile)
+	// Create PID file, it will be removed while cleaning up. Memory database
+	// doesn't lock anything, so PID file is not needed.
+	if pidfile := c.PIDFile; pidfile != "" && !inMemory {
+		pidFile, err = pid.New(pidfile)
 		if err != nil {
 			logrus.Errorf("PID create failed for %v.", err)
 			return err
 		}
-		defer func() {
-			err = p.Remove()
-			if err != nil {
-				logrus.Errorf("PID remove failed for %v.", err)
-			}
-		}()
 	}
 
 	// Setup contexts.
@@ -48,9 +70,137 @@ func initContext(configFile string) error {
 	return nil
 }
 
+// loadConfig will load and check config without setting up contexts.
+func loadConfig(configFile string) (*config.Config, error) {
+	c := &config.Config{}
+	if err := c.LoadFromFilePath(configFile); err != nil {
+		logrus.Errorf("Load config from %s failed for %v.", configFile, err)
+		return nil, err
+	}
+
+	// Check config.
+	if err := c.Check(); err != nil {
+		logrus.Errorf("Config check failed for %v.", err)
+		return nil, err
+	}
+	return c, nil
+}
+
+// sendControl will send control request r to the running qscamel.
+func sendControl(configFile string, r *control.Request) error {
+	// Running qscamel holds the database, so we only load config here.
+	c, err := loadConfig(configFile)
+	if err != nil {
+		return err
+	}
+
+	err = checkRunning(c)
+	if err != nil {
+		logrus.Errorf("Check PID file %s failed for %v, qscamel may be not running.", c.PIDFile, err)
+		return err
+	}
+
+	return control.Send(c.ControlFile, r, nil)
+}
+
+// checkRunning will check whether qscamel is running via PID file, nil
+// will be returned if it's running.
+func checkRunning(c *config.Config) error {
+	content, err := ioutil.ReadFile(c.PIDFile)
+	if err != nil {
+		return err
+	}
+	id, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return err
+	}
+	if p, err := os.FindProcess(id); err != nil || p.Signal(syscall.Signal(0)) != nil {
+		return constants.ErrProcessNotRunning
+	}
+	return nil
+}
+
+// serveControl will accept control requests for the running task, qscamel
+// can still run without it.
+func serveControl() *control.Server {
+	s, err := control.Listen(contexts.Config.ControlFile, migrate.HandleControl)
+	if err != nil {
+		logrus.Errorf("Control listen on %s failed for %v.", contexts.Config.ControlFile, err)
+		return nil
+	}
+	return s
+}
+
 func cleanUp() error {
 	if contexts.DB != nil {
 		contexts.DB.Close()
 	}
+	if pidFile != nil {
+		err := pidFile.Remove()
+		if err != nil {
+			logrus.Errorf("PID remove failed for %v.", err)
+		}
+		pidFile = nil
+	}
 	return nil
 }
+
+// handleSignals will cancel the task while SIGINT or SIGTERM received, and
+// exit immediately while received again. The first signal will be sent to
+// the returned channel before the task canceled.
+func handleSignals(cancel context.CancelFunc) <-chan os.Signal {
+	sigs := make(chan os.Signal, 2)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+	received := make(chan os.Signal, 1)
+	go func() {
+		sig := <-sigs
+		logrus.Infof("Signal %v received, waiting for running objects, send again to exit immediately.", sig)
+		received <- sig
+		cancel()
+
+		sig = <-sigs
+		logrus.Infof("Signal %v received again, exit for now.", sig)
+		migrate.SaveTask()
+		cleanUp()
+		os.Exit(exitCode(sig))
+	}()
+	return received
+}
+
+// checkInterrupted will tell whether the task has been interrupted by signal,
+// and flush the task if so.
+func checkInterrupted(received <-chan os.Signal, closePrint chan struct{}) bool {
+	select {
+	case sig := <-received:
+		select {
+		case closePrint <- struct{}{}:
+		default:
+		}
+		migrate.SaveTask()
+
+		logrus.Infof("Task interrupted by signal %v, run it again to resume.", sig)
+		ExitCode = exitCode(sig)
+		return true
+	default:
+		return false
+	}
+}
+
+// errorExitCode will return the exit code for task failed with err.
+func errorExitCode(err error) int {
+	// Sync task aborted by delete threshold will not be finished until the
+	// threshold raised, which should be noticed by caller.
+	if err == constants.ErrTaskDeleteThresholdExceeded {
+		return constants.ExitCodeDeleteThresholdExceeded
+	}
+	return 0
+}
+
+// exitCode will return the exit code for signal sig.
+func exitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return constants.ExitCodeSignalBase + int(s)
+	}
+	return constants.ExitCodeSignalBase
+}
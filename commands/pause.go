@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/control"
+)
+
+// PauseCmd will pause a running task.
+var PauseCmd = &cobra.Command{
+	Use:   "pause [task name]",
+	Short: "Pause a running task after running objects finished",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := sendControl(cmd.Flag("config").Value.String(), &control.Request{
+			Task:    args[0],
+			Command: constants.ControlCommandPause,
+		})
+		if err != nil {
+			logrus.Errorf("Pause task %s failed for %v.", args[0], err)
+			return
+		}
+		logrus.Infof("Task %s has been paused.", args[0])
+	},
+}
+
+// ResumeCmd will resume a paused task.
+var ResumeCmd = &cobra.Command{
+	Use:   "resume [task name]",
+	Short: "Resume a paused task",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := sendControl(cmd.Flag("config").Value.String(), &control.Request{
+			Task:    args[0],
+			Command: constants.ControlCommandResume,
+		})
+		if err != nil {
+			logrus.Errorf("Resume task %s failed for %v.", args[0], err)
+			return
+		}
+		logrus.Infof("Task %s has been resumed.", args[0])
+	},
+}
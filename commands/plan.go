@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/migrate"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// planTaskName is the name of task that only exists in memory database.
+const planTaskName = "plan"
+
+// PlanCmd will provide plan command for qscamel.
+var PlanCmd = &cobra.Command{
+	Use:   "plan [task path]",
+	Short: "Show what a task would do without running it",
+	Args:  cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		// Plan should not touch the database file.
+		return initPlanContext(cmd.Flag("config").Value.String())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+
+		// Load and check task.
+		t, err := model.LoadTask(planTaskName, args[0])
+		if err != nil {
+			logrus.Errorf("Task load failed for %v.", err)
+			return
+		}
+		err = t.Check()
+		if err != nil {
+			logrus.Errorf("Task check failed for %v.", err)
+			return
+		}
+
+		ctx = utils.NewTaskContext(ctx, t.Name)
+
+		logrus.Infof("Current version: %s.", constants.Version)
+		logrus.Infof("Task %s plan started.", args[0])
+
+		r, err := migrate.Plan(ctx)
+		if err != nil {
+			logrus.Errorf("Plan failed for %v.", err)
+			return
+		}
+
+		fmt.Printf("Task type: %s\n", r.Type)
+		fmt.Printf("To %s: %d objects, %d bytes\n", r.Type, r.HandleCount, r.HandleSize)
+		fmt.Printf("Multipart: %d objects, %d bytes\n", r.MultipartCount, r.MultipartSize)
+		fmt.Printf("Skipped: %d objects, %d bytes\n", r.SkipCount, r.SkipSize)
+		fmt.Printf("Failed: %d objects\n", r.FailedCount)
+		if r.Type == constants.TaskTypeSync {
+			fmt.Printf("To delete: %d of %d destination objects\n", r.DeleteCount, r.DestinationCount)
+		}
+	},
+	PostRunE: func(cmd *cobra.Command, args []string) error {
+		return cleanUp()
+	},
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/yunify/qscamel/model"
+)
+
+var (
+	reportFormat string
+	reportOutput string
+)
+
+// ReportCmd will provide report command for qscamel.
+var ReportCmd = &cobra.Command{
+	Use:   "report [task name]",
+	Short: "Export the report of a verify task",
+	Args:  cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		return initContext(cmd.Flag("config").Value.String())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := newReportExporter().run(args[0], reportOutput, reportFormat)
+		if err != nil {
+			logrus.Errorf("Export report failed for %v.", err)
+		}
+	},
+	PostRunE: func(cmd *cobra.Command, args []string) error {
+		return cleanUp()
+	},
+}
+
+// newReportExporter will create an exporter for all reports of a task.
+func newReportExporter() *exporter {
+	p := ""
+	return &exporter{
+		header: []string{"key", "type", "src_size", "dst_size", "src_md5", "dst_md5"},
+		row: func(v interface{}) []string {
+			r := v.(*model.Report)
+			return []string{
+				r.Key, r.Type,
+				strconv.FormatInt(r.SrcSize, 10), strconv.FormatInt(r.DstSize, 10),
+				r.SrcMD5, r.DstMD5,
+			}
+		},
+		next: func(ctx context.Context) (interface{}, error) {
+			r, err := model.NextReport(ctx, p)
+			if err != nil || r == nil {
+				return nil, err
+			}
+			p = r.Key
+			return r, nil
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/migrate"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// RetryCmd will provide retry command for qscamel.
+var RetryCmd = &cobra.Command{
+	Use:   "retry [task name]",
+	Short: "Retry the failed objects of a task",
+	Args:  cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		return initContext(cmd.Flag("config").Value.String())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var closePrint = make(chan struct{}, 1)
+		received := handleSignals(cancel)
+
+		// Load task.
+		t, err := model.LoadTask(args[0], "")
+		if err != nil {
+			logrus.Errorf("Task load failed for %v.", err)
+			return
+		}
+
+		ctx = utils.NewTaskContext(ctx, t.Name)
+
+		// Accept control requests while task running.
+		cs := serveControl()
+		defer cs.Close()
+
+		logrus.Infof("Current version: %s.", constants.Version)
+		logrus.Infof("Task %s retry started.", t.Name)
+
+		err = migrate.Retry(ctx, closePrint)
+		if checkInterrupted(received, closePrint) {
+			return
+		}
+		if err != nil {
+			logrus.Errorf("Retry failed for %v.", err)
+			ExitCode = errorExitCode(err)
+		}
+	},
+	PostRunE: func(cmd *cobra.Command, args []string) error {
+		return cleanUp()
+	},
+}
@@ -2,8 +2,6 @@ package commands
 
 import (
 	"context"
-	"os"
-	"os/signal"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -27,21 +25,11 @@ var RunCmd = &cobra.Command{
 		return initContext(cmd.Flag("config").Value.String())
 	},
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 
 		var closePrint = make(chan struct{}, 1)
-		sigs := make(chan os.Signal, 1)
-		signal.Notify(sigs, os.Interrupt, os.Kill)
-		go func() {
-			sig := <-sigs
-			logrus.Infof("Signal %v received, exit for now.", sig)
-
-			closePrint <- struct{}{}
-			migrate.SaveTask()
-
-			cleanUp()
-			os.Exit(0)
-		}()
+		received := handleSignals(cancel)
 
 		// Load and check task.
 		t, err := model.LoadTask(args[0], taskPath)
@@ -57,13 +45,21 @@ var RunCmd = &cobra.Command{
 
 		ctx = utils.NewTaskContext(ctx, t.Name)
 
+		// Accept control requests while task running.
+		cs := serveControl()
+		defer cs.Close()
+
 		// Start migrate.
 		logrus.Infof("Current version: %s.", constants.Version)
 		logrus.Infof("Task %s migrate started.", t.Name)
 
 		err = migrate.Execute(ctx, closePrint)
+		if checkInterrupted(received, closePrint) {
+			return
+		}
 		if err != nil {
 			logrus.Errorf("Migrate failed for %v.", err)
+			ExitCode = errorExitCode(err)
 		}
 	},
 	PostRunE: func(cmd *cobra.Command, args []string) error {
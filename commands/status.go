@@ -2,35 +2,148 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/control"
 	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+var (
+	statusOutput string
 )
 
 // StatusCmd will show current task status.
 var StatusCmd = &cobra.Command{
-	Use:   "status",
+	Use:   "status [task name]",
 	Short: "Show current task status.",
-	PreRunE: func(cmd *cobra.Command, _ []string) error {
-		return initContext(cmd.Flag("config").Value.String())
-	},
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		// Start show status.
-		logrus.Infof("Show status started.")
+		c, err := loadConfig(cmd.Flag("config").Value.String())
+		if err != nil {
+			return
+		}
+
+		// Running qscamel holds the database, so the status of running task
+		// will be served by itself.
+		if checkRunning(c) == nil {
+			if len(args) == 0 {
+				logrus.Errorf("qscamel is running, only the status of running task can be shown.")
+				return
+			}
+
+			s := &model.TaskStatus{}
+			err = control.Send(c.ControlFile, &control.Request{
+				Task:    args[0],
+				Command: constants.ControlCommandStatus,
+			}, s)
+			if err != nil {
+				logrus.Errorf("Get task %s status failed for %v.", args[0], err)
+				return
+			}
+
+			err = writeStatus(os.Stdout, s, statusOutput)
+			if err != nil {
+				logrus.Errorf("Show task %s status failed for %v.", args[0], err)
+			}
+			return
+		}
+
+		// No qscamel is running, read the database directly without creating
+		// PID file.
+		err = contexts.SetupContexts(c)
+		if err != nil {
+			logrus.Errorf("Contexts setup failed for %v.", err)
+			return
+		}
 
 		ctx := context.Background()
-		t, err := model.ListTask(ctx)
+
+		if len(args) == 0 {
+			// Start show status.
+			logrus.Infof("Show status started.")
+
+			t, err := model.ListTask(ctx)
+			if err != nil {
+				logrus.Panic(err)
+			}
+			logrus.Printf("There are %d tasks totally.", len(t))
+			for _, v := range t {
+				logrus.Printf("Task: %s, Status: %s", v.Name, v.Status)
+			}
+			return
+		}
+
+		t, err := model.GetTaskByName(ctx, args[0])
 		if err != nil {
-			logrus.Panic(err)
+			logrus.Panicf("Task load failed for %v.", err)
+			return
 		}
-		logrus.Printf("There are %d tasks totally.", len(t))
-		for _, v := range t {
-			logrus.Printf("Task: %s, Status: %s", v.Name, v.Status)
+		if t == nil {
+			logrus.Errorf("Task %s is not exist.", args[0])
+			return
+		}
+
+		ctx = utils.NewTaskContext(ctx, t.Name)
+
+		s, err := model.NewTaskStatus(ctx, t)
+		if err != nil {
+			logrus.Errorf("Get task %s status failed for %v.", t.Name, err)
+			return
+		}
+
+		err = writeStatus(os.Stdout, s, statusOutput)
+		if err != nil {
+			logrus.Errorf("Show task %s status failed for %v.", t.Name, err)
 		}
 	},
 	PostRunE: func(cmd *cobra.Command, args []string) error {
 		return cleanUp()
 	},
 }
+
+// writeStatus will write task status into w.
+func writeStatus(w io.Writer, s *model.TaskStatus, format string) (err error) {
+	switch format {
+	case constants.StatusOutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	case constants.StatusOutputYAML:
+		content, err := yaml.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(content)
+		return err
+	case constants.StatusOutputTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "Name:\t%s\n", s.Name)
+		fmt.Fprintf(tw, "Type:\t%s\n", s.Type)
+		fmt.Fprintf(tw, "Status:\t%s\n", s.Status)
+		fmt.Fprintf(tw, "Pending directory objects:\t%d\n", s.PendingDirectoryObjects)
+		fmt.Fprintf(tw, "Pending single objects:\t%d\n", s.PendingSingleObjects)
+		fmt.Fprintf(tw, "Pending partial objects:\t%d\n", s.PendingPartialObjects)
+		fmt.Fprintf(tw, "Success count:\t%d\n", s.SuccessCount)
+		fmt.Fprintf(tw, "Success size:\t%d\n", s.SuccessSize)
+		fmt.Fprintf(tw, "Failed count:\t%d\n", s.FailedCount)
+		fmt.Fprintf(tw, "Created at:\t%s\n", s.CreatedAt)
+		fmt.Fprintf(tw, "Started at:\t%s\n", s.StartedAt)
+		fmt.Fprintf(tw, "Finished at:\t%s\n", s.FinishedAt)
+		fmt.Fprintf(tw, "Throughput:\t%.2f B/s\n", s.Throughput)
+		return tw.Flush()
+	default:
+		logrus.Errorf("Status output %s is not supported.", format)
+		return constants.ErrTaskInvalid
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"gopkg.in/yaml.v2"
 
@@ -17,8 +18,12 @@ type Config struct {
 	LogFile      string `yaml:"log_file"`
 	LogLevel     string `yaml:"log_level"`
 	PIDFile      string `yaml:"pid_file"`
+	ControlFile  string `yaml:"control_file"`
 	DatabaseFile string `yaml:"database_file"`
 	Proxy        string `yaml:"proxy"`
+
+	// InMemoryDatabase will make qscamel not touch the database file.
+	InMemoryDatabase bool `yaml:"-"`
 }
 
 // New will create a new Config.
@@ -81,6 +86,19 @@ func (c *Config) Check() (err error) {
 		return
 	}
 
+	// Check control file.
+	if c.ControlFile == "" {
+		c.ControlFile = constants.ControlPath
+	}
+	c.ControlFile, err = utils.Expand(c.ControlFile)
+	if err != nil {
+		return
+	}
+	err = os.MkdirAll(filepath.Dir(c.ControlFile), os.ModeDir|0777)
+	if err != nil {
+		return
+	}
+
 	// Check log file.
 	if c.LogFile == "" {
 		c.LogFile = constants.LogPath
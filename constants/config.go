@@ -7,6 +7,7 @@ const DefaultConfigContent = `concurrency: 0
 log_file: ~/.qscamel/qscamel.log
 log_level: info
 pid_file: ~/.qscamel/qscamel.pid
+control_file: ~/.qscamel/qscamel.sock
 database_file: ~/.qscamel/db
 `
 
@@ -22,8 +23,24 @@ const (
 	DatabasePath = Path + "/db"
 	LogPath      = Path + "/qscamel.log"
 	PIDPath      = Path + "/qscamel.pid"
+	ControlPath  = Path + "/qscamel.sock"
 )
 
 // DefaultMultipartBoundarySize is the default multipart boundary size.
 // 2 * 1024 * 1024 * 1024 = 2147483648 B = 2 GB
 const DefaultMultipartBoundarySize = 2147483648
+
+// MaxDeleteBatchSize is the max number of objects to delete in one batch.
+const MaxDeleteBatchSize = 1000
+
+// ExitCodeSignalBase is the base exit code while task interrupted by signal,
+// the signal number will be added to it as shells do.
+const ExitCodeSignalBase = 128
+
+// ExitCodeDeleteThresholdExceeded is the exit code while sync task aborted
+// by delete threshold.
+const ExitCodeDeleteThresholdExceeded = 3
+
+// BandwidthBurstSize is the max bytes that can be read at once while
+// bandwidth is limited.
+const BandwidthBurstSize = 64 * 1024
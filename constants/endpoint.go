@@ -5,12 +5,15 @@ const (
 	EndpointAliyun   = "aliyun"
 	EndpointAzblob   = "azblob"
 	EndpointFileList = "filelist"
+	EndpointFTP      = "ftp"
 	EndpointFs       = "fs"
 	EndpointGCS      = "gcs"
 	EndpointHDFS     = "hdfs"
+	EndpointHTTP     = "http"
 	EndpointQingStor = "qingstor"
 	EndpointQiniu    = "qiniu"
 	EndpointS3       = "s3"
+	EndpointSFTP     = "sftp"
 	EndpointUpyun    = "upyun"
 	EndpointCOS      = "cos"
 )
@@ -20,3 +23,7 @@ const (
 	SourceEndpoint uint8 = iota
 	DestinationEndpoint
 )
+
+// QSMetaPrefix is the header prefix of QingStor user defined metadata, which
+// is kept in metadata keys listed from QingStor.
+const QSMetaPrefix = "x-qs-meta-"
@@ -12,6 +12,11 @@ var (
 	ErrTaskNotFinished = errors.New("task not finished")
 	// ErrTaskNotFound is returned when task is not found.
 	ErrTaskNotFound = errors.New("task not found")
+	// ErrTaskNotStarted is returned when task has not been started.
+	ErrTaskNotStarted = errors.New("task not started")
+	// ErrTaskDeleteThresholdExceeded is returned when sync task would delete
+	// too many destination objects.
+	ErrTaskDeleteThresholdExceeded = errors.New("task delete threshold exceeded")
 
 	// ErrEndpointInvalid is returned when this endpoint is invalid.
 	ErrEndpointInvalid = errors.New("endpoint is invalid")
@@ -24,4 +29,15 @@ var (
 	ErrObjectTooLarge = errors.New("object is too large")
 	// ErrObjectInvalid is returned when the object is invalid.
 	ErrObjectInvalid = errors.New("object is invalid")
+	// ErrObjectMismatch is returned when the object is not the same in src and dst.
+	ErrObjectMismatch = errors.New("object is mismatch")
+	// ErrObjectKeyMappingInvalid is returned when object is mapped to an invalid or conflicted key.
+	ErrObjectKeyMappingInvalid = errors.New("object key mapping is invalid")
+
+	// ErrControlInvalid is returned when the control request is invalid.
+	ErrControlInvalid = errors.New("control request is invalid")
+	// ErrControlInUse is returned when the control file is used by another process.
+	ErrControlInUse = errors.New("control file is in use")
+	// ErrProcessNotRunning is returned when the process in PID file is not running.
+	ErrProcessNotRunning = errors.New("process is not running")
 )
@@ -10,6 +10,9 @@ const (
 	TaskTypeCopy   = "copy"
 	TaskTypeDelete = "delete"
 	TaskTypeFetch  = "fetch"
+	TaskTypeSync   = "sync"
+	TaskTypeVerify = "verify"
+	TaskTypeMove   = "move"
 )
 
 // Constants for task status.
@@ -40,6 +43,59 @@ const (
 	ObjectTypePartial   = "partial"
 )
 
+// Constants for key mapping rule types.
+const (
+	KeyMappingStripPrefix = "strip_prefix"
+	KeyMappingAddPrefix   = "add_prefix"
+	KeyMappingDatePrefix  = "date_prefix"
+	KeyMappingLowercase   = "lowercase"
+	KeyMappingRegex       = "regex"
+)
+
+// Constants for verify report types.
+const (
+	ReportTypeMissing      = "missing"
+	ReportTypeSizeMismatch = "size_mismatch"
+	ReportTypeMD5Mismatch  = "md5_mismatch"
+)
+
+// Constants for failure phases.
+const (
+	FailurePhaseRead     = "read"
+	FailurePhaseWrite    = "write"
+	FailurePhaseCheck    = "check"
+	FailurePhaseComplete = "complete"
+	FailurePhaseDelete   = "delete"
+)
+
+// Constants for control commands.
+const (
+	ControlCommandBandwidth = "bandwidth"
+	ControlCommandPause     = "pause"
+	ControlCommandResume    = "resume"
+	ControlCommandStatus    = "status"
+)
+
+// Constants for bandwidth limit targets.
+const (
+	BandwidthTargetTask        = "task"
+	BandwidthTargetSource      = "source"
+	BandwidthTargetDestination = "destination"
+)
+
+// Constants for report export formats.
+const (
+	ReportFormatCSV   = "csv"
+	ReportFormatJSONL = "jsonl"
+)
+
+// Constants for status output formats.
+const (
+	StatusOutputJSON  = "json"
+	StatusOutputYAML  = "yaml"
+	StatusOutputTable = "table"
+)
+
 // Constants for database key.
 const (
 	KeyTaskPrefix = "t:"
@@ -50,6 +106,14 @@ const (
 	KeyDirectoryObjectPrefix = "do:"
 	KeySingleObjectPrefix    = "so:"
 	KeyPartialObjectPrefix   = "po:"
+	KeyReportPrefix          = "rp:"
+	KeyMappingPrefix         = "km:"
+	KeyFailurePrefix         = "fl:"
+	KeyUploadPrefix          = "up:"
+
+	// KeyDestinationSuffix is appended to task name to store objects
+	// listed from destination.
+	KeyDestinationSuffix = ":dst"
 )
 
 // FormatTaskKey will format a task key.
@@ -57,6 +121,12 @@ func FormatTaskKey(t string) []byte {
 	return []byte(KeyTaskPrefix + t)
 }
 
+// FormatDestinationTaskName will format the name which is used to store
+// destination objects for a task.
+func FormatDestinationTaskName(t string) string {
+	return t + KeyDestinationSuffix
+}
+
 // FormatDirectoryObjectKey will format a directory object key.
 func FormatDirectoryObjectKey(t, s string) []byte {
 	buf := buffer.GlobalBytesPool().Get()
@@ -89,6 +159,22 @@ func FormatSingleObjectKey(t, s string) []byte {
 	return b
 }
 
+// FormatReportKey will format a report key.
+func FormatReportKey(t, s string) []byte {
+	buf := buffer.GlobalBytesPool().Get()
+	defer buf.Free()
+
+	buf.AppendString(ObjectPrefixKey)
+	buf.AppendString(t)
+	buf.AppendString(":")
+	buf.AppendString(KeyReportPrefix)
+	buf.AppendString(s)
+
+	b := make([]byte, buf.Len())
+	copy(b, buf.Bytes())
+	return b
+}
+
 // FormatPartialObjectKey will format a partial object key.
 func FormatPartialObjectKey(t, s string, partNumber int) []byte {
 	buf := buffer.GlobalBytesPool().Get()
@@ -115,3 +201,51 @@ func FormatPartialObjectKey(t, s string, partNumber int) []byte {
 	copy(b, buf.Bytes())
 	return b
 }
+
+// FormatKeyMappingKey will format a key mapping key.
+func FormatKeyMappingKey(t, s string) []byte {
+	buf := buffer.GlobalBytesPool().Get()
+	defer buf.Free()
+
+	buf.AppendString(ObjectPrefixKey)
+	buf.AppendString(t)
+	buf.AppendString(":")
+	buf.AppendString(KeyMappingPrefix)
+	buf.AppendString(s)
+
+	b := make([]byte, buf.Len())
+	copy(b, buf.Bytes())
+	return b
+}
+
+// FormatFailureKey will format a failure key.
+func FormatFailureKey(t, s string) []byte {
+	buf := buffer.GlobalBytesPool().Get()
+	defer buf.Free()
+
+	buf.AppendString(ObjectPrefixKey)
+	buf.AppendString(t)
+	buf.AppendString(":")
+	buf.AppendString(KeyFailurePrefix)
+	buf.AppendString(s)
+
+	b := make([]byte, buf.Len())
+	copy(b, buf.Bytes())
+	return b
+}
+
+// FormatUploadKey will format an upload key.
+func FormatUploadKey(t, s string) []byte {
+	buf := buffer.GlobalBytesPool().Get()
+	defer buf.Free()
+
+	buf.AppendString(ObjectPrefixKey)
+	buf.AppendString(t)
+	buf.AppendString(":")
+	buf.AppendString(KeyUploadPrefix)
+	buf.AppendString(s)
+
+	b := make([]byte, buf.Len())
+	copy(b, buf.Bytes())
+	return b
+}
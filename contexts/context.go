@@ -54,7 +54,8 @@ func SetupContexts(c *config.Config) (err error) {
 
 	// Setup Bolt.
 	DB, err = db.NewDB(&db.DatabaseOptions{
-		Address: c.DatabaseFile,
+		Address:  c.DatabaseFile,
+		InMemory: c.InMemoryDatabase,
 	})
 	if err != nil {
 		return
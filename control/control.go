@@ -0,0 +1,135 @@
+package control
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/utils"
+)
+
+// Request is a command sent to the running qscamel.
+type Request struct {
+	Task    string `json:"task"`
+	Command string `json:"command"`
+	Target  string `json:"target,omitempty"`
+	Value   int64  `json:"value,omitempty"`
+}
+
+// Response is the result of a request.
+type Response struct {
+	Error string          `json:"error,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+}
+
+// Handler will handle a request, v will be sent back as response data if
+// it's not nil.
+type Handler func(r *Request) (v interface{}, err error)
+
+// Server will accept requests from control file.
+type Server struct {
+	l net.Listener
+	h Handler
+}
+
+// Listen will listen on the control file p and handle requests with h.
+func Listen(p string, h Handler) (s *Server, err error) {
+	// Control file which can be connected is used by another qscamel.
+	if c, err := net.Dial("unix", p); err == nil {
+		c.Close()
+		return nil, constants.ErrControlInUse
+	}
+	err = os.Remove(p)
+	if err != nil && !os.IsNotExist(err) {
+		return
+	}
+
+	l, err := net.Listen("unix", p)
+	if err != nil {
+		return
+	}
+
+	s = &Server{l: l, h: h}
+	go s.serve()
+	return
+}
+
+// Close will stop accepting requests and remove the control file.
+func (s *Server) Close() error {
+	if s == nil {
+		return nil
+	}
+	return s.l.Close()
+}
+
+func (s *Server) serve() {
+	for {
+		c, err := s.l.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(c)
+	}
+}
+
+func (s *Server) handle(c net.Conn) {
+	defer c.Close()
+	defer utils.Recover()
+
+	r := &Request{}
+	err := json.NewDecoder(c).Decode(r)
+	if err != nil {
+		logrus.Errorf("Decode control request failed for %v.", err)
+		return
+	}
+	logrus.Infof("Control request %s received.", r.Command)
+
+	resp := &Response{}
+	v, err := s.h(r)
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	if err == nil && v != nil {
+		resp.Data, err = json.Marshal(v)
+		if err != nil {
+			resp.Error = err.Error()
+		}
+	}
+
+	err = json.NewEncoder(c).Encode(resp)
+	if err != nil {
+		logrus.Errorf("Encode control response failed for %v.", err)
+	}
+}
+
+// Send will send request r to the qscamel which listens on control file p,
+// response data will be decoded into v if v is not nil.
+func Send(p string, r *Request, v interface{}) (err error) {
+	c, err := net.Dial("unix", p)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+
+	err = json.NewEncoder(c).Encode(r)
+	if err != nil {
+		return
+	}
+
+	resp := &Response{}
+	err = json.NewDecoder(c).Decode(resp)
+	if err != nil {
+		return
+	}
+	if resp.Error != "" {
+		return errors.New(resp.Error)
+	}
+	if v != nil && resp.Data != nil {
+		return json.Unmarshal(resp.Data, v)
+	}
+	return
+}
@@ -0,0 +1,49 @@
+package control
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yunify/qscamel/constants"
+)
+
+func TestControl(t *testing.T) {
+	dir, err := ioutil.TempDir("", "qscamel-control")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, "qscamel.sock")
+
+	var got *Request
+	s, err := Listen(p, func(r *Request) (interface{}, error) {
+		got = r
+		if r.Task != "test" {
+			return nil, errors.New("task not match")
+		}
+		return r, nil
+	})
+	assert.NoError(t, err)
+
+	_, err = Listen(p, nil)
+	assert.Equal(t, constants.ErrControlInUse, err)
+
+	r := &Request{Task: "test", Command: constants.ControlCommandBandwidth, Value: 1024}
+	assert.NoError(t, Send(p, r, nil))
+	assert.Equal(t, r, got)
+
+	// Response data should be decoded.
+	v := &Request{}
+	assert.NoError(t, Send(p, r, v))
+	assert.Equal(t, r, v)
+
+	err = Send(p, &Request{Task: "other"}, nil)
+	assert.EqualError(t, err, "task not match")
+
+	assert.NoError(t, s.Close())
+	assert.Error(t, Send(p, r, nil))
+}
@@ -15,10 +15,23 @@ type Database struct {
 // DatabaseOptions stores database options.
 type DatabaseOptions struct {
 	Address string
+	// InMemory will make database only keep in memory, address will be
+	// ignored.
+	InMemory bool
 }
 
 // NewDB will create a new database connection.
 func NewDB(opt *DatabaseOptions) (d *Database, err error) {
+	if opt.InMemory {
+		client, err := leveldb.Open(storage.NewMemStorage(), nil)
+		if err != nil {
+			logrus.Errorf("Open memory database failed for %v.", err)
+			return nil, err
+		}
+		logrus.Debugf("Connected to memory database")
+		return &Database{DB: client}, nil
+	}
+
 	// Set NoFreelistSync to true to import write performance.
 	client, err := leveldb.OpenFile(opt.Address, nil)
 	if err == nil {
@@ -10,7 +10,6 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/yunify/qscamel/model"
-	"github.com/yunify/qscamel/utils"
 )
 
 // Name implement base.Read
@@ -20,7 +19,7 @@ func (c *Client) Name(ctx context.Context) (name string) {
 
 // Read implement source.Read
 func (c *Client) Read(ctx context.Context, p string, _ bool) (r io.Reader, err error) {
-	cp := utils.Join(c.Path, p)
+	cp := c.objectKey(p)
 
 	r, err = c.client.GetObject(cp)
 	if err != nil {
@@ -34,7 +33,7 @@ func (c *Client) Read(ctx context.Context, p string, _ bool) (r io.Reader, err e
 func (c *Client) ReadRange(
 	ctx context.Context, p string, offset, size int64,
 ) (r io.Reader, err error) {
-	cp := utils.Join(c.Path, p)
+	cp := c.objectKey(p)
 
 	r, err = c.client.GetObject(cp, oss.Range(offset, offset+size-1))
 	if err != nil {
@@ -46,15 +45,13 @@ func (c *Client) ReadRange(
 
 // Stat implement source.Stat and destination.Stat
 func (c *Client) Stat(ctx context.Context, p string, _ bool) (o *model.SingleObject, err error) {
-	cp := utils.Join(c.Path, p)
+	cp := c.objectKey(p)
 
 	resp, err := c.client.GetObjectMeta(cp)
 	if err != nil {
-		if e, ok := err.(*oss.ServiceError); ok {
-			// If object not found, we just need to return a nil object.
-			if e.StatusCode == 404 {
-				return nil, nil
-			}
+		// If object not found, we just need to return a nil object.
+		if e, ok := err.(oss.ServiceError); ok && e.StatusCode == 404 {
+			return nil, nil
 		}
 		logrus.Errorf("Stat failed for %v.", err)
 		return
@@ -3,6 +3,7 @@ package aliyun
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/sirupsen/logrus"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
 )
 
 // Client is the client to visit aliyun oss service.
@@ -19,9 +21,15 @@ type Client struct {
 	AccessKeyID     string `yaml:"access_key_id"`
 	AccessKeySecret string `yaml:"access_key_secret"`
 
+	// Whether to migrate custom metadata
+	UserDefineMeta bool `yaml:"user_define_meta"`
+	// PresignExpire is the expire seconds of presigned url used in fetch.
+	PresignExpire int64 `yaml:"presign_expire"`
+
 	Path string
 
-	client *oss.Bucket
+	client  *oss.Bucket
+	service *oss.Client
 }
 
 // New will create a client.
@@ -75,17 +83,29 @@ func New(ctx context.Context, et uint8, _ *http.Client) (c *Client, err error) {
 		return
 	}
 
+	// Set presign expire.
+	c.PresignExpire, err = utils.CheckPresignExpire("Aliyun OSS", c.PresignExpire, DefaultPresignExpire)
+	if err != nil {
+		return
+	}
+
 	// Set prefix.
 	c.Path = e.Path
 
-	service, err := oss.New(c.Endpoint, c.AccessKeyID, c.AccessKeySecret)
+	c.service, err = oss.New(c.Endpoint, c.AccessKeyID, c.AccessKeySecret)
 	if err != nil {
 		return
 	}
-	c.client, err = service.Bucket(c.BucketName)
+	c.client, err = c.service.Bucket(c.BucketName)
 	if err != nil {
 		return
 	}
 
 	return
 }
+
+// objectKey will build the oss object key for p.
+// OSS doesn't allow object key starts with "/", so we need to trim it.
+func (c *Client) objectKey(p string) string {
+	return utils.Join(c.Path, strings.TrimPrefix(p, "/"))
+}
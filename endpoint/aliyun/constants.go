@@ -1,4 +1,37 @@
 package aliyun
 
+import "time"
+
 // MaxKeys is the max limit for list objects.
 const MaxKeys = 1000
+
+// MaxListPartsLimit is the max limit for list uploaded parts.
+const MaxListPartsLimit = 1000
+
+// Multipart related constants.
+const (
+	// DefaultMultipartSize is the default multipart size.
+	// 64 * 1024 * 1024 = 67108864 B = 64 MB
+	DefaultMultipartSize = 67108864
+	// MaxAutoMultipartSize is the max auto multipart size.
+	// If part size is over MaxAutoMultipartSize, we will not detect it any more.
+	// 1024 * 1024 * 1024 = 1073741824 B = 1 GB
+	MaxAutoMultipartSize = 1073741824
+	// MaxMultipartNumber is the max part that OSS supported.
+	MaxMultipartNumber = 10000
+	// MaxMultipartBoundarySize is the max multipart boundary size.
+	// 5 * 1024 * 1024 * 1024 = 5368709120 B = 5 GB
+	MaxMultipartBoundarySize = 5368709120
+)
+
+// Async fetch task states returned by OSS.
+const (
+	FetchStateSuccess = "Success"
+	FetchStateFailed  = "Failed"
+)
+
+// FetchCheckInterval is the interval to check async fetch task state.
+const FetchCheckInterval = time.Second
+
+// DefaultPresignExpire is the default expire seconds for presigned url.
+const DefaultPresignExpire = 3600
@@ -0,0 +1,234 @@
+package aliyun
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// Deletable implement destination.Deletable
+func (c *Client) Deletable() bool {
+	return true
+}
+
+// Fetchable implement destination.Fetchable
+func (c *Client) Fetchable() bool {
+	return true
+}
+
+// Writable implement destination.Writable
+func (c *Client) Writable() bool {
+	return true
+}
+
+// Delete implement destination.Delete
+func (c *Client) Delete(ctx context.Context, p string) (err error) {
+	cp := c.objectKey(p)
+
+	err = c.client.DeleteObject(cp)
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("Aliyun OSS delete object %s.", cp)
+	return
+}
+
+// Write implement destination.Write
+func (c *Client) Write(ctx context.Context, p string, size int64, r io.Reader, isDir bool, meta map[string]string) (err error) {
+	cp := c.objectKey(p)
+
+	if isDir {
+		// Directory object is an empty object whose key ends with "/".
+		if !strings.HasSuffix(cp, "/") {
+			cp += "/"
+		}
+		r = bytes.NewReader(nil)
+		size = 0
+	}
+
+	opts := append(c.metaOptions(meta), oss.ContentLength(size))
+
+	// wrap by limitReader to keep body consistent with size
+	err = c.client.PutObject(cp, io.LimitReader(r, size), opts...)
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("Aliyun OSS wrote object %s.", cp)
+	return
+}
+
+// Fetch implement destination.Fetch
+func (c *Client) Fetch(ctx context.Context, p, url string) (err error) {
+	cp := c.objectKey(p)
+
+	resp, err := c.service.SetBucketAsyncTask(c.BucketName, oss.AsyncFetchTaskConfiguration{
+		Url:    url,
+		Object: cp,
+	})
+	if err != nil {
+		return
+	}
+
+	// OSS fetch is an async task, we need to wait for it finished.
+	for {
+		info, err := c.service.GetBucketAsyncTask(c.BucketName, resp.TaskId)
+		if err != nil {
+			return err
+		}
+
+		switch info.State {
+		case FetchStateSuccess:
+			logrus.Debugf("Aliyun OSS fetched object %s.", cp)
+			return nil
+		case FetchStateFailed:
+			return fmt.Errorf("aliyun oss fetch task %s failed: %s", resp.TaskId, info.ErrorMsg)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(FetchCheckInterval):
+		}
+	}
+}
+
+// Partable implement destination.Partable
+func (c *Client) Partable() bool {
+	return true
+}
+
+// InitPart implement destination.InitPart
+func (c *Client) InitPart(ctx context.Context, p string, size int64, meta map[string]string) (uploadID string, partSize int64, partNumbers int, err error) {
+	cp := c.objectKey(p)
+
+	resp, err := c.client.InitiateMultipartUpload(cp, c.metaOptions(meta)...)
+	if err != nil {
+		return
+	}
+
+	uploadID = resp.UploadID
+	partSize, err = calculatePartSize(size)
+	if err != nil {
+		logrus.Errorf("Object %s is too large", p)
+		return
+	}
+
+	partNumbers = int(size / partSize)
+	if size%partSize != 0 {
+		partNumbers++
+	}
+	return
+}
+
+// UploadPart implement destination.UploadPart
+func (c *Client) UploadPart(ctx context.Context, o *model.PartialObject, r io.Reader) (err error) {
+	cp := c.objectKey(o.Key)
+
+	// OSS part number starts from 1, but ours starts from 0.
+	_, err = c.client.UploadPart(
+		c.uploadResult(cp, o.UploadID),
+		// wrap by limitReader to keep body consistent with size
+		io.LimitReader(r, o.Size),
+		o.Size,
+		o.PartNumber+1,
+	)
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("Aliyun OSS wrote partial object %s at %d.", o.Key, o.Offset)
+	return nil
+}
+
+// CompleteParts implement destination.CompleteParts
+func (c *Client) CompleteParts(ctx context.Context, path string, uploadId string, totalNumber int) (err error) {
+	cp := c.objectKey(path)
+	imur := c.uploadResult(cp, uploadId)
+
+	logrus.Infof("Object %s start completing part", path)
+
+	// OSS requires every part's etag while completing, so we need to
+	// list all uploaded parts here.
+	parts := make([]oss.UploadPart, 0, totalNumber)
+	marker := 0
+	for {
+		resp, err := c.client.ListUploadedParts(imur,
+			oss.MaxParts(MaxListPartsLimit),
+			oss.PartNumberMarker(marker),
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range resp.UploadedParts {
+			parts = append(parts, oss.UploadPart{
+				PartNumber: v.PartNumber,
+				ETag:       v.ETag,
+			})
+			marker = v.PartNumber
+		}
+
+		if !resp.IsTruncated {
+			break
+		}
+	}
+
+	if len(parts) != totalNumber {
+		return fmt.Errorf("object %s expected %d parts but got %d", path, totalNumber, len(parts))
+	}
+
+	_, err = c.client.CompleteMultipartUpload(imur, parts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AbortUploads implement destination.AbortUploads
+func (c *Client) AbortUploads(ctx context.Context, path string, uploadId string) (err error) {
+	cp := c.objectKey(path)
+
+	logrus.Infof("Object %s start abort part", path)
+
+	err = c.client.AbortMultipartUpload(c.uploadResult(cp, uploadId))
+	if err != nil {
+		return err
+	}
+
+	return
+}
+
+// uploadResult will build the multipart upload handle used by oss sdk.
+func (c *Client) uploadResult(cp, uploadID string) oss.InitiateMultipartUploadResult {
+	return oss.InitiateMultipartUploadResult{
+		Bucket:   c.BucketName,
+		Key:      cp,
+		UploadID: uploadID,
+	}
+}
+
+// metaOptions will convert object metadata into oss options.
+func (c *Client) metaOptions(meta map[string]string) (opts []oss.Option) {
+	for k, v := range meta {
+		if k == "ContentType" {
+			opts = append(opts, oss.ContentType(v))
+			continue
+		}
+		if c.UserDefineMeta {
+			opts = append(opts, oss.Meta(utils.TrimQSMetaPrefix(k), v))
+		}
+	}
+	return
+}
@@ -0,0 +1,265 @@
+package aliyun
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yunify/qscamel/model"
+)
+
+// fakeOSS is a minimal in-memory stand-in for the OSS API used by destination.
+type fakeOSS struct {
+	sync.Mutex
+
+	objects map[string][]byte
+	headers map[string]http.Header
+	uploads map[string]map[int][]byte
+	fetches map[string]string
+}
+
+func newFakeOSS() *fakeOSS {
+	return &fakeOSS{
+		objects: make(map[string][]byte),
+		headers: make(map[string]http.Header),
+		uploads: make(map[string]map[int][]byte),
+		fetches: make(map[string]string),
+	}
+}
+
+func (f *fakeOSS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	// Requests to an ip endpoint are path style: /bucket/key
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	key := ""
+	if len(parts) == 2 {
+		key = parts[1]
+	}
+	q := r.URL.Query()
+	body, _ := ioutil.ReadAll(r.Body)
+
+	switch {
+	case hasQuery(q, "asyncFetch"):
+		if r.Method == http.MethodPost {
+			conf := oss.AsyncFetchTaskConfiguration{}
+			_ = xml.Unmarshal(body, &conf)
+			resp, err := http.Get(conf.Url)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			content, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			f.objects[conf.Object] = content
+			id := strconv.Itoa(len(f.fetches))
+			f.fetches[id] = conf.Object
+			writeXML(w, oss.AsyncFetchTaskResult{TaskId: id})
+			return
+		}
+		id := r.Header.Get(oss.HTTPHeaderOssTaskID)
+		writeXML(w, oss.AsynFetchTaskInfo{TaskId: id, State: FetchStateSuccess})
+	case hasQuery(q, "uploads"):
+		id := fmt.Sprintf("upload-%d", len(f.uploads))
+		f.uploads[id] = make(map[int][]byte)
+		f.headers[key] = r.Header.Clone()
+		writeXML(w, oss.InitiateMultipartUploadResult{Key: key, UploadID: id})
+	case q.Get("uploadId") != "":
+		id := q.Get("uploadId")
+		up, ok := f.uploads[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			pn, _ := strconv.Atoi(q.Get("partNumber"))
+			up[pn] = body
+			w.Header().Set("ETag", fmt.Sprintf("\"etag-%d\"", pn))
+		case http.MethodGet:
+			res := oss.ListUploadedPartsResult{UploadID: id}
+			for pn := range up {
+				res.UploadedParts = append(res.UploadedParts, oss.UploadedPart{
+					PartNumber: pn,
+					ETag:       fmt.Sprintf("\"etag-%d\"", pn),
+				})
+			}
+			sort.Slice(res.UploadedParts, func(i, j int) bool {
+				return res.UploadedParts[i].PartNumber < res.UploadedParts[j].PartNumber
+			})
+			writeXML(w, res)
+		case http.MethodPost:
+			pns := make([]int, 0, len(up))
+			for pn := range up {
+				pns = append(pns, pn)
+			}
+			sort.Ints(pns)
+			buf := &bytes.Buffer{}
+			for _, pn := range pns {
+				buf.Write(up[pn])
+			}
+			f.objects[key] = buf.Bytes()
+			delete(f.uploads, id)
+			writeXML(w, oss.CompleteMultipartUploadResult{Key: key})
+		case http.MethodDelete:
+			delete(f.uploads, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case r.Method == http.MethodPut:
+		f.objects[key] = body
+		f.headers[key] = r.Header.Clone()
+		w.Header().Set("ETag", "\"etag\"")
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodHead:
+		content, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Header().Set("ETag", "\"etag\"")
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func hasQuery(q map[string][]string, k string) bool {
+	_, ok := q[k]
+	return ok
+}
+
+func writeXML(w http.ResponseWriter, v interface{}) {
+	content, _ := xml.Marshal(v)
+	w.Header().Set("Content-Type", "application/xml")
+	_, _ = w.Write(content)
+}
+
+func newTestClient(t *testing.T, f *fakeOSS) (*Client, func()) {
+	srv := httptest.NewServer(f)
+
+	c := &Client{
+		Endpoint:       srv.URL,
+		BucketName:     "bucket",
+		UserDefineMeta: true,
+		Path:           "prefix",
+	}
+	var err error
+	c.service, err = oss.New(srv.URL, "id", "secret")
+	assert.NoError(t, err)
+	c.client, err = c.service.Bucket(c.BucketName)
+	assert.NoError(t, err)
+
+	return c, srv.Close
+}
+
+func TestClient_Write(t *testing.T) {
+	f := newFakeOSS()
+	c, closer := newTestClient(t, f)
+	defer closer()
+
+	ctx := context.Background()
+	content := []byte("hello, qscamel")
+
+	err := c.Write(ctx, "/a/b.txt", int64(len(content)), bytes.NewReader(content), false,
+		map[string]string{"ContentType": "text/plain", "x-qs-meta-owner": "qscamel"})
+	assert.NoError(t, err)
+	assert.Equal(t, content, f.objects["prefix/a/b.txt"])
+	assert.Equal(t, "text/plain", f.headers["prefix/a/b.txt"].Get("Content-Type"))
+	assert.Equal(t, "qscamel", f.headers["prefix/a/b.txt"].Get("X-Oss-Meta-Owner"))
+	assert.Empty(t, f.headers["prefix/a/b.txt"].Get("X-Oss-Meta-X-Qs-Meta-Owner"))
+
+	o, err := c.Stat(ctx, "/a/b.txt", false)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(content)), o.Size)
+
+	err = c.Write(ctx, "dir/", 0, nil, true, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, f.objects, "prefix/dir/")
+
+	err = c.Delete(ctx, "/a/b.txt")
+	assert.NoError(t, err)
+	o, err = c.Stat(ctx, "/a/b.txt", false)
+	assert.NoError(t, err)
+	assert.Nil(t, o)
+}
+
+func TestClient_Multipart(t *testing.T) {
+	f := newFakeOSS()
+	c, closer := newTestClient(t, f)
+	defer closer()
+
+	ctx := context.Background()
+	content := []byte("0123456789abcdefghij")
+
+	uploadID, partSize, partNumbers, err := c.InitPart(ctx, "large", int64(len(content)), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(DefaultMultipartSize), partSize)
+	assert.Equal(t, 1, partNumbers)
+
+	// Upload parts in reverse order to make sure parts are sorted while
+	// completing.
+	for pn := partNumbers - 1; pn >= 0; pn-- {
+		offset := int64(pn) * partSize
+		end := offset + partSize
+		if end > int64(len(content)) {
+			end = int64(len(content))
+		}
+		err = c.UploadPart(ctx, &model.PartialObject{
+			Key:        "large",
+			Size:       end - offset,
+			Offset:     offset,
+			PartNumber: pn,
+			UploadID:   uploadID,
+		}, bytes.NewReader(content[offset:end]))
+		assert.NoError(t, err)
+	}
+	assert.Contains(t, f.uploads[uploadID], 1, "oss part number should start from 1")
+	assert.Len(t, f.uploads[uploadID], partNumbers)
+
+	err = c.CompleteParts(ctx, "large", uploadID, partNumbers+1)
+	assert.Error(t, err, "complete should fail while parts are missing")
+
+	err = c.CompleteParts(ctx, "large", uploadID, partNumbers)
+	assert.NoError(t, err)
+	assert.Equal(t, content, f.objects["prefix/large"])
+
+	// Large object should be split by part size.
+	uploadID, partSize, partNumbers, err = c.InitPart(ctx, "aborted", 2*DefaultMultipartSize+1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(DefaultMultipartSize), partSize)
+	assert.Equal(t, 3, partNumbers)
+	err = c.AbortUploads(ctx, "aborted", uploadID)
+	assert.NoError(t, err)
+	assert.NotContains(t, f.uploads, uploadID)
+}
+
+func TestClient_Fetch(t *testing.T) {
+	f := newFakeOSS()
+	c, closer := newTestClient(t, f)
+	defer closer()
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("fetched"))
+	}))
+	defer origin.Close()
+
+	err := c.Fetch(context.Background(), "/fetch.txt", origin.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("fetched"), f.objects["prefix/fetch.txt"])
+}
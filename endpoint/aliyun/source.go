@@ -7,7 +7,6 @@ import (
 	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/sirupsen/logrus"
 
-	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/model"
 	"github.com/yunify/qscamel/utils"
 )
@@ -71,10 +70,10 @@ func (c *Client) List(ctx context.Context, j *model.DirectoryObject, fn func(o m
 
 // Reach implement source.Fetch
 func (c *Client) Reach(ctx context.Context, p string) (url string, err error) {
-	return "", constants.ErrEndpointFuncNotImplemented
+	return c.client.SignURL(c.objectKey(p), oss.HTTPGet, c.PresignExpire)
 }
 
 // Reachable implement source.Reachable
 func (c *Client) Reachable() bool {
-	return false
+	return true
 }
@@ -3,17 +3,22 @@ package azblob
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/Xuanwo/storage"
 	"github.com/Xuanwo/storage/pkg/credential"
 	"github.com/Xuanwo/storage/pkg/endpoint"
-	"github.com/Xuanwo/storage/services/azblob"
+	xazblob "github.com/Xuanwo/storage/services/azblob"
 	"github.com/Xuanwo/storage/types/pairs"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 
 	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
 )
 
 // Client is the client to visit service.
@@ -23,9 +28,19 @@ type Client struct {
 	BucketName  string `yaml:"bucket_name"`
 	Endpoint    string `yaml:"endpoint"`
 
+	// Whether to migrate custom metadata
+	UserDefineMeta bool `yaml:"user_define_meta"`
+	// PresignExpire is the expire seconds of presigned url used in fetch.
+	PresignExpire int64 `yaml:"presign_expire"`
+
 	Path string
 
 	client storage.Storager
+	// container is used for operations that storage.Storager doesn't
+	// support, such as block staging.
+	container azblob.ContainerURL
+	// credential is used to sign requests and SAS tokens.
+	credential *azblob.SharedKeyCredential
 }
 
 // New will create a new client.
@@ -69,14 +84,53 @@ func New(ctx context.Context, et uint8, _ *http.Client) (c *Client, err error) {
 		return
 	}
 
+	// Set presign expire.
+	c.PresignExpire, err = utils.CheckPresignExpire("Azure blob storage", c.PresignExpire, DefaultPresignExpire)
+	if err != nil {
+		return
+	}
+
 	// Set path.
 	c.Path = e.Path
 
-	_, c.client, err = azblob.New(
+	ep := endpoint.NewHTTPS(c.Endpoint, 443)
+
+	_, c.client, err = xazblob.New(
 		pairs.WithCredential(credential.MustNewHmac(c.AccountName, c.AccountKey)),
 		pairs.WithName(c.BucketName),
 		pairs.WithWorkDir(c.Path),
-		pairs.WithEndpoint(endpoint.NewHTTPS(c.Endpoint, 443)),
+		pairs.WithEndpoint(ep),
 	)
+	if err != nil {
+		return
+	}
+
+	c.credential, err = azblob.NewSharedKeyCredential(c.AccountName, c.AccountKey)
+	if err != nil {
+		return
+	}
+	u, err := url.Parse(ep.Value().String())
+	if err != nil {
+		return
+	}
+	p := azblob.NewPipeline(c.credential, azblob.PipelineOptions{
+		// We don't need sdk level retry, qscamel will retry by itself.
+		Retry: azblob.RetryOptions{
+			MaxTries:   1,
+			TryTimeout: 720 * time.Hour,
+		},
+	})
+	c.container = azblob.NewServiceURL(*u, p).NewContainerURL(c.BucketName)
 	return
 }
+
+// blobURL will return the block blob url for p.
+// The path must be the same as storage.Storager used in Stat and Delete.
+func (c *Client) blobURL(p string) azblob.BlockBlobURL {
+	return c.container.NewBlockBlobURL(c.blobName(p))
+}
+
+// blobName will return the blob name for p.
+func (c *Client) blobName(p string) string {
+	return strings.TrimPrefix(c.Path, "/") + p
+}
@@ -0,0 +1,26 @@
+package azblob
+
+// Block blob related constants.
+// ref: https://docs.microsoft.com/en-us/rest/api/storageservices/put-block
+const (
+	// DefaultMultipartSize is the default block size.
+	// 64 * 1024 * 1024 = 67108864 B = 64 MB
+	DefaultMultipartSize = 67108864
+	// MaxMultipartNumber is the max uncommitted blocks that a blob supported.
+	MaxMultipartNumber = 50000
+	// MaxMultipartBoundarySize is the max block size.
+	// 100 * 1024 * 1024 = 104857600 B = 100 MB
+	MaxMultipartBoundarySize = 104857600
+)
+
+// Write related constants.
+const (
+	// WriteBufferSize is the block size used while writing a whole object.
+	// 4 * 1024 * 1024 = 4194304 B = 4 MB
+	WriteBufferSize = 4194304
+	// WriteMaxBuffers is the max buffers used while writing a whole object.
+	WriteMaxBuffers = 2
+)
+
+// DefaultPresignExpire is the default expire seconds for presigned url.
+const DefaultPresignExpire = 3600
@@ -0,0 +1,186 @@
+package azblob
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// blobMeta stores the headers and metadata for a blob.
+type blobMeta struct {
+	headers  azblob.BlobHTTPHeaders
+	metadata azblob.Metadata
+}
+
+// Deletable implement destination.Deletable
+func (c *Client) Deletable() bool {
+	return true
+}
+
+// Fetchable implement destination.Fetchable
+func (c *Client) Fetchable() bool {
+	return false
+}
+
+// Writable implement destination.Writable
+func (c *Client) Writable() bool {
+	return true
+}
+
+// Delete implement destination.Delete
+func (c *Client) Delete(ctx context.Context, p string) (err error) {
+	err = c.client.DeleteWithContext(ctx, p)
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("Azblob delete object %s.", p)
+	return
+}
+
+// Write implement destination.Write
+func (c *Client) Write(ctx context.Context, p string, size int64, r io.Reader, isDir bool, meta map[string]string) (err error) {
+	// Azure blob storage doesn't have directory, skip it.
+	if isDir {
+		return nil
+	}
+
+	m := c.blobMeta(meta)
+
+	_, err = azblob.UploadStreamToBlockBlob(ctx,
+		// wrap by limitReader to keep body consistent with size
+		io.LimitReader(r, size),
+		c.blobURL(p),
+		azblob.UploadStreamToBlockBlobOptions{
+			BufferSize:      WriteBufferSize,
+			MaxBuffers:      WriteMaxBuffers,
+			BlobHTTPHeaders: m.headers,
+			Metadata:        m.metadata,
+		})
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("Azblob wrote object %s.", p)
+	return
+}
+
+// Fetch implement destination.Fetch
+func (c *Client) Fetch(ctx context.Context, p, url string) (err error) {
+	return constants.ErrEndpointFuncNotImplemented
+}
+
+// Partable implement destination.Partable
+func (c *Client) Partable() bool {
+	return true
+}
+
+// InitPart implement destination.InitPart
+//
+// Azure blob storage doesn't need to initiate a multipart upload, blocks
+// will be staged by Put Block and committed by Put Block List.
+// We generate an upload id here as the block id prefix, so that every
+// block id could be rebuilt from the partial object.
+func (c *Client) InitPart(ctx context.Context, p string, size int64, meta map[string]string) (uploadID string, partSize int64, partNumbers int, err error) {
+	uploadID, err = newUploadID()
+	if err != nil {
+		return
+	}
+
+	partSize, err = calculatePartSize(size)
+	if err != nil {
+		logrus.Errorf("Object %s is too large", p)
+		return
+	}
+
+	partNumbers = int(size / partSize)
+	if size%partSize != 0 {
+		partNumbers++
+	}
+
+	// Headers and metadata can only be set while committing, store them
+	// so that the upload can be completed after qscamel restarted.
+	err = model.CreateUpload(ctx, &model.Upload{
+		ID:       uploadID,
+		Key:      p,
+		Metadata: meta,
+	})
+	return
+}
+
+// UploadPart implement destination.UploadPart
+func (c *Client) UploadPart(ctx context.Context, o *model.PartialObject, r io.Reader) (err error) {
+	_, err = c.blobURL(o.Key).StageBlock(ctx,
+		formatBlockID(o.UploadID, o.PartNumber),
+		newSizedReader(r, o.Size),
+		azblob.LeaseAccessConditions{}, nil)
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("Azblob staged partial object %s at %d.", o.Key, o.Offset)
+	return nil
+}
+
+// CompleteParts implement destination.CompleteParts
+func (c *Client) CompleteParts(ctx context.Context, path string, uploadId string, totalNumber int) (err error) {
+	logrus.Infof("Object %s start completing part", path)
+
+	ids := make([]string, totalNumber)
+	for i := 0; i < totalNumber; i++ {
+		ids[i] = formatBlockID(uploadId, i)
+	}
+
+	u, err := model.GetUpload(ctx, uploadId)
+	if err != nil {
+		return
+	}
+	m := blobMeta{}
+	if u != nil {
+		m = c.blobMeta(u.Metadata)
+	}
+
+	_, err = c.blobURL(path).CommitBlockList(ctx, ids,
+		m.headers, m.metadata, azblob.BlobAccessConditions{})
+	if err != nil {
+		return err
+	}
+
+	return model.DeleteUpload(ctx, uploadId)
+}
+
+// AbortUploads implement destination.AbortUploads
+//
+// Azure blob storage doesn't support abort staged blocks, uncommitted
+// blocks will be garbage collected by azure after a week.
+func (c *Client) AbortUploads(ctx context.Context, path string, uploadId string) (err error) {
+	logrus.Infof("Object %s start abort part", path)
+
+	return model.DeleteUpload(ctx, uploadId)
+}
+
+// blobMeta will convert object metadata into blob headers and metadata.
+func (c *Client) blobMeta(meta map[string]string) (m blobMeta) {
+	for k, v := range meta {
+		if k == "ContentType" {
+			m.headers.ContentType = v
+			continue
+		}
+		if c.UserDefineMeta {
+			if m.metadata == nil {
+				m.metadata = azblob.Metadata{}
+			}
+			// Azure blob metadata key must be a valid C# identifier.
+			k = utils.TrimQSMetaPrefix(k)
+			m.metadata[strings.ToLower(strings.Replace(k, "-", "_", -1))] = v
+		}
+	}
+	return
+}
@@ -0,0 +1,25 @@
+package azblob
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_BlobMeta(t *testing.T) {
+	c := &Client{UserDefineMeta: true}
+
+	m := c.blobMeta(map[string]string{
+		"ContentType":          "text/plain",
+		"x-qs-meta-owner":      "qscamel",
+		"x-qs-meta-created-by": "test",
+	})
+	assert.Equal(t, "text/plain", m.headers.ContentType)
+	// Metadata listed from QingStor should not keep its prefix.
+	assert.Equal(t, azblob.Metadata{"owner": "qscamel", "created_by": "test"}, m.metadata)
+
+	c.UserDefineMeta = false
+	m = c.blobMeta(map[string]string{"x-qs-meta-owner": "qscamel"})
+	assert.Nil(t, m.metadata)
+}
@@ -2,11 +2,12 @@ package azblob
 
 import (
 	"context"
+	"time"
 
+	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/Xuanwo/storage"
 	"github.com/Xuanwo/storage/types"
 	"github.com/Xuanwo/storage/types/pairs"
-	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/model"
 )
 
@@ -30,10 +31,24 @@ func (c *Client) List(ctx context.Context, j *model.DirectoryObject, fn func(o m
 
 // Reach implement source.Fetch
 func (c *Client) Reach(ctx context.Context, p string) (url string, err error) {
-	return "", constants.ErrEndpointFuncNotImplemented
+	sas, err := azblob.BlobSASSignatureValues{
+		Protocol:      azblob.SASProtocolHTTPS,
+		ExpiryTime:    time.Now().UTC().Add(time.Duration(c.PresignExpire) * time.Second),
+		ContainerName: c.BucketName,
+		BlobName:      c.blobName(p),
+		Permissions:   azblob.BlobSASPermissions{Read: true}.String(),
+	}.NewSASQueryParameters(c.credential)
+	if err != nil {
+		return
+	}
+
+	parts := azblob.NewBlobURLParts(c.blobURL(p).URL())
+	parts.SAS = sas
+	u := parts.URL()
+	return u.String(), nil
 }
 
 // Reachable implement source.Reachable
 func (c *Client) Reachable() bool {
-	return false
+	return true
 }
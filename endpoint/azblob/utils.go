@@ -0,0 +1,102 @@
+package azblob
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/yunify/qscamel/constants"
+)
+
+// calculatePartSize will calculate the object's block size.
+func calculatePartSize(size int64) (partSize int64, err error) {
+	partSize = DefaultMultipartSize
+
+	if size/partSize >= int64(MaxMultipartNumber) {
+		// Try to adjust partSize if it is too small and account for
+		// integer division truncation.
+		partSize = size/int64(MaxMultipartNumber) + 1
+	}
+
+	if partSize > MaxMultipartBoundarySize {
+		err = constants.ErrObjectTooLarge
+		return
+	}
+
+	return
+}
+
+// newUploadID will generate a random upload id, azblob doesn't have
+// upload id, we use it as the block id prefix.
+func newUploadID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// formatBlockID will format the block id for a part.
+// All block ids in a blob must have the same length, so part number
+// should be padded.
+func formatBlockID(uploadID string, partNumber int) string {
+	return base64.StdEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("%s-%05d", uploadID, partNumber)))
+}
+
+// errUnseekable is returned when seek after read.
+var errUnseekable = errors.New("reader can't seek after read")
+
+// sizedReader is a reader with known size which could be used as
+// io.ReadSeeker before any read happened.
+//
+// Azure sdk requires io.ReadSeeker to detect the body's length and
+// rewind it, so we can stream part content without buffering it.
+type sizedReader struct {
+	r    io.Reader
+	size int64
+
+	pos  int64
+	read int64
+}
+
+func newSizedReader(r io.Reader, size int64) *sizedReader {
+	return &sizedReader{
+		// wrap by limitReader to keep body consistent with size
+		r:    io.LimitReader(r, size),
+		size: size,
+	}
+}
+
+// Read implement io.Reader
+func (s *sizedReader) Read(p []byte) (n int, err error) {
+	if s.pos != s.read {
+		return 0, errUnseekable
+	}
+	n, err = s.r.Read(p)
+	s.read += int64(n)
+	s.pos = s.read
+	return
+}
+
+// Seek implement io.Seeker
+func (s *sizedReader) Seek(offset int64, whence int) (int64, error) {
+	var pos int64
+	switch whence {
+	case io.SeekStart:
+		pos = offset
+	case io.SeekCurrent:
+		pos = s.pos + offset
+	case io.SeekEnd:
+		pos = s.size + offset
+	}
+	if s.read > 0 && pos != s.read {
+		return 0, errUnseekable
+	}
+	s.pos = pos
+	return pos, nil
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/tencentyun/cos-go-sdk-v5"
 
 	"github.com/yunify/qscamel/model"
-	"github.com/yunify/qscamel/utils"
 )
 
 // Name implement base.Read
@@ -20,7 +19,7 @@ func (c *Client) Name(ctx context.Context) (name string) {
 
 // Read implement source.Read
 func (c *Client) Read(ctx context.Context, p string, _ bool) (r io.Reader, err error) {
-	cp := utils.Join(c.Path, p)
+	cp := c.objectKey(p)
 
 	resp, err := c.client.Object.Get(ctx, cp, nil)
 	if err != nil {
@@ -34,7 +33,7 @@ func (c *Client) Read(ctx context.Context, p string, _ bool) (r io.Reader, err e
 func (c *Client) ReadRange(
 	ctx context.Context, p string, offset, size int64,
 ) (r io.Reader, err error) {
-	cp := utils.Join(c.Path, p)
+	cp := c.objectKey(p)
 
 	opt := &cos.ObjectGetOptions{
 		Range: fmt.Sprintf("bytes=%d-%d", offset, offset+size-1),
@@ -49,7 +48,7 @@ func (c *Client) ReadRange(
 
 // Stat implement source.Stat and destination.Stat
 func (c *Client) Stat(ctx context.Context, p string, _ bool) (o *model.SingleObject, err error) {
-	cp := utils.Join(c.Path, p)
+	cp := c.objectKey(p)
 
 	resp, err := c.client.Object.Head(ctx, cp, nil)
 	if err != nil {
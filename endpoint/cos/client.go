@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
 )
 
 // Client is the client to visit aliyun oss service.
@@ -20,6 +22,11 @@ type Client struct {
 	SecretID  string `yaml:"secret_id"`
 	SecretKey string `yaml:"secret_key"`
 
+	// Whether to migrate custom metadata
+	UserDefineMeta bool `yaml:"user_define_meta"`
+	// PresignExpire is the expire seconds of presigned url used in fetch.
+	PresignExpire int64 `yaml:"presign_expire"`
+
 	Path string
 
 	client *cos.Client
@@ -73,6 +80,12 @@ func New(ctx context.Context, et uint8, _ *http.Client) (c *Client, err error) {
 		return
 	}
 
+	// Set presign expire.
+	c.PresignExpire, err = utils.CheckPresignExpire("Tencent COS", c.PresignExpire, DefaultPresignExpire)
+	if err != nil {
+		return
+	}
+
 	// Set prefix.
 	c.Path = e.Path
 	b := &cos.BaseURL{BucketURL: u}
@@ -85,3 +98,9 @@ func New(ctx context.Context, et uint8, _ *http.Client) (c *Client, err error) {
 	})
 	return
 }
+
+// objectKey will build the cos object key for p.
+// COS object key should not start with "/", so we need to trim it.
+func (c *Client) objectKey(p string) string {
+	return utils.Join(c.Path, strings.TrimPrefix(p, "/"))
+}
@@ -2,3 +2,28 @@ package cos
 
 // MaxKeys is the max limit for list objects.
 const MaxKeys = 1000
+
+// MaxListPartsLimit is the max limit for list uploaded parts.
+const MaxListPartsLimit = 1000
+
+// MetaPrefix is the header prefix for user defined metadata.
+const MetaPrefix = "x-cos-meta-"
+
+// Multipart related constants.
+const (
+	// DefaultMultipartSize is the default multipart size.
+	// 64 * 1024 * 1024 = 67108864 B = 64 MB
+	DefaultMultipartSize = 67108864
+	// MaxAutoMultipartSize is the max auto multipart size.
+	// If part size is over MaxAutoMultipartSize, we will not detect it any more.
+	// 1024 * 1024 * 1024 = 1073741824 B = 1 GB
+	MaxAutoMultipartSize = 1073741824
+	// MaxMultipartNumber is the max part that COS supported.
+	MaxMultipartNumber = 10000
+	// MaxMultipartBoundarySize is the max multipart boundary size.
+	// 5 * 1024 * 1024 * 1024 = 5368709120 B = 5 GB
+	MaxMultipartBoundarySize = 5368709120
+)
+
+// DefaultPresignExpire is the default expire seconds for presigned url.
+const DefaultPresignExpire = 3600
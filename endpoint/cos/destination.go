@@ -0,0 +1,208 @@
+package cos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tencentyun/cos-go-sdk-v5"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// Deletable implement destination.Deletable
+func (c *Client) Deletable() bool {
+	return true
+}
+
+// Fetchable implement destination.Fetchable
+func (c *Client) Fetchable() bool {
+	return false
+}
+
+// Writable implement destination.Writable
+func (c *Client) Writable() bool {
+	return true
+}
+
+// Delete implement destination.Delete
+func (c *Client) Delete(ctx context.Context, p string) (err error) {
+	cp := c.objectKey(p)
+
+	_, err = c.client.Object.Delete(ctx, cp)
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("Tencent COS delete object %s.", cp)
+	return
+}
+
+// Write implement destination.Write
+func (c *Client) Write(ctx context.Context, p string, size int64, r io.Reader, isDir bool, meta map[string]string) (err error) {
+	cp := c.objectKey(p)
+
+	if isDir {
+		// Directory object is an empty object whose key ends with "/".
+		if !strings.HasSuffix(cp, "/") {
+			cp += "/"
+		}
+		r = bytes.NewReader(nil)
+		size = 0
+	}
+
+	opt := &cos.ObjectPutOptions{
+		ObjectPutHeaderOptions: c.headerOptions(meta),
+	}
+	opt.ContentLength = int(size)
+
+	// wrap by limitReader to keep body consistent with size
+	_, err = c.client.Object.Put(ctx, cp, io.LimitReader(r, size), opt)
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("Tencent COS wrote object %s.", cp)
+	return
+}
+
+// Fetch implement destination.Fetch
+func (c *Client) Fetch(ctx context.Context, p, url string) (err error) {
+	return constants.ErrEndpointFuncNotImplemented
+}
+
+// Partable implement destination.Partable
+func (c *Client) Partable() bool {
+	return true
+}
+
+// InitPart implement destination.InitPart
+func (c *Client) InitPart(ctx context.Context, p string, size int64, meta map[string]string) (uploadID string, partSize int64, partNumbers int, err error) {
+	cp := c.objectKey(p)
+
+	resp, _, err := c.client.Object.InitiateMultipartUpload(ctx, cp, &cos.InitiateMultipartUploadOptions{
+		ObjectPutHeaderOptions: c.headerOptions(meta),
+	})
+	if err != nil {
+		return
+	}
+
+	uploadID = resp.UploadID
+	partSize, err = calculatePartSize(size)
+	if err != nil {
+		logrus.Errorf("Object %s is too large", p)
+		return
+	}
+
+	partNumbers = int(size / partSize)
+	if size%partSize != 0 {
+		partNumbers++
+	}
+	return
+}
+
+// UploadPart implement destination.UploadPart
+func (c *Client) UploadPart(ctx context.Context, o *model.PartialObject, r io.Reader) (err error) {
+	cp := c.objectKey(o.Key)
+
+	// COS part number starts from 1, but ours starts from 0.
+	_, err = c.client.Object.UploadPart(ctx, cp, o.UploadID, o.PartNumber+1,
+		// wrap by limitReader to keep body consistent with size
+		io.LimitReader(r, o.Size),
+		&cos.ObjectUploadPartOptions{
+			ContentLength: int(o.Size),
+		})
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("Tencent COS wrote partial object %s at %d.", o.Key, o.Offset)
+	return nil
+}
+
+// CompleteParts implement destination.CompleteParts
+func (c *Client) CompleteParts(ctx context.Context, path string, uploadId string, totalNumber int) (err error) {
+	cp := c.objectKey(path)
+
+	logrus.Infof("Object %s start completing part", path)
+
+	// COS requires every part's etag while completing, so we need to
+	// list all uploaded parts here.
+	parts := make([]cos.Object, 0, totalNumber)
+	marker := ""
+	for {
+		resp, _, err := c.client.Object.ListParts(ctx, cp, uploadId, &cos.ObjectListPartsOptions{
+			MaxParts:         strconv.Itoa(MaxListPartsLimit),
+			PartNumberMarker: marker,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, v := range resp.Parts {
+			parts = append(parts, cos.Object{
+				PartNumber: v.PartNumber,
+				ETag:       v.ETag,
+			})
+		}
+
+		marker = resp.NextPartNumberMarker
+		if !resp.IsTruncated || marker == "" {
+			break
+		}
+	}
+
+	if len(parts) != totalNumber {
+		return fmt.Errorf("object %s expected %d parts but got %d", path, totalNumber, len(parts))
+	}
+
+	_, _, err = c.client.Object.CompleteMultipartUpload(ctx, cp, uploadId, &cos.CompleteMultipartUploadOptions{
+		Parts: parts,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AbortUploads implement destination.AbortUploads
+func (c *Client) AbortUploads(ctx context.Context, path string, uploadId string) (err error) {
+	cp := c.objectKey(path)
+
+	logrus.Infof("Object %s start abort part", path)
+
+	_, err = c.client.Object.AbortMultipartUpload(ctx, cp, uploadId)
+	if err != nil {
+		return err
+	}
+
+	return
+}
+
+// headerOptions will convert object metadata into cos put header options.
+func (c *Client) headerOptions(meta map[string]string) *cos.ObjectPutHeaderOptions {
+	opt := &cos.ObjectPutHeaderOptions{}
+
+	h := http.Header{}
+	for k, v := range meta {
+		if k == "ContentType" {
+			opt.ContentType = v
+			continue
+		}
+		if c.UserDefineMeta {
+			h.Add(MetaPrefix+strings.ToLower(utils.TrimQSMetaPrefix(k)), v)
+		}
+	}
+	if len(h) > 0 {
+		opt.XCosMetaXXX = &h
+	}
+	return opt
+}
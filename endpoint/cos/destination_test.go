@@ -0,0 +1,48 @@
+package cos
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tencentyun/cos-go-sdk-v5"
+)
+
+func TestClient_Write(t *testing.T) {
+	var (
+		header http.Header
+		path   string
+		body   []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		path = r.URL.Path
+		body, _ = ioutil.ReadAll(r.Body)
+		w.Header().Set("ETag", "\"etag\"")
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	assert.NoError(t, err)
+	c := &Client{
+		UserDefineMeta: true,
+		Path:           "prefix",
+		client:         cos.NewClient(&cos.BaseURL{BucketURL: u}, srv.Client()),
+	}
+
+	content := []byte("hello, qscamel")
+	err = c.Write(context.Background(), "/a/b.txt", int64(len(content)), bytes.NewReader(content), false,
+		map[string]string{"ContentType": "text/plain", "x-qs-meta-owner": "qscamel"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/prefix/a/b.txt", path)
+	assert.Equal(t, content, body)
+	assert.Equal(t, "text/plain", header.Get("Content-Type"))
+	// Metadata listed from QingStor should be set with cos prefix only.
+	assert.Equal(t, "qscamel", header.Get("X-Cos-Meta-Owner"))
+	assert.Empty(t, header.Get("X-Cos-Meta-X-Qs-Meta-Owner"))
+}
@@ -2,11 +2,12 @@ package cos
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/tencentyun/cos-go-sdk-v5"
 
-	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/model"
 	"github.com/yunify/qscamel/utils"
 )
@@ -68,10 +69,15 @@ func (c *Client) List(ctx context.Context, j *model.DirectoryObject, fn func(o m
 
 // Reach implement source.Fetch
 func (c *Client) Reach(ctx context.Context, p string) (url string, err error) {
-	return "", constants.ErrEndpointFuncNotImplemented
+	u, err := c.client.Object.GetPresignedURL(ctx, http.MethodGet, c.objectKey(p),
+		c.SecretID, c.SecretKey, time.Duration(c.PresignExpire)*time.Second, nil)
+	if err != nil {
+		return
+	}
+	return u.String(), nil
 }
 
 // Reachable implement source.Reachable
 func (c *Client) Reachable() bool {
-	return false
+	return true
 }
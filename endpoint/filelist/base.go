@@ -57,5 +57,16 @@ func (c *Client) Stat(ctx context.Context, p string, _ bool) (o *model.SingleObj
 		Size:         fi.Size(),
 		LastModified: fi.ModTime().Unix(),
 	}
+
+	// Metadata in structured list takes precedence.
+	if e, ok := c.entries[cleanKey(p)]; ok {
+		if e.Size != nil {
+			o.Size = *e.Size
+		}
+		if e.LastModified != nil {
+			o.LastModified = *e.LastModified
+		}
+		o.MD5 = e.MD5
+	}
 	return
 }
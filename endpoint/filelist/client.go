@@ -1,20 +1,30 @@
 package filelist
 
 import (
+	"bufio"
 	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
 	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/model"
-	"gopkg.in/yaml.v2"
-	"path/filepath"
 )
 
 // Client is the struct for local file list endpoint.
 type Client struct {
-	ListPath string `yaml:"list_path"`
+	ListPath   string `yaml:"list_path"`
+	ListFormat string `yaml:"list_format"`
 
 	Path string
 
 	AbsPath string
+
+	// entries stores metadata in structured list, which will be used
+	// while stating.
+	entries map[string]*entry
 }
 
 // New will create a new file list client.
@@ -47,5 +57,47 @@ func New(ctx context.Context, et uint8) (c *Client, err error) {
 		return
 	}
 
+	// Set list format.
+	switch c.ListFormat {
+	case "":
+		c.ListFormat = ListFormatPlain
+	case ListFormatPlain:
+	case ListFormatCSV, ListFormatJSONL:
+		err = c.load()
+		if err != nil {
+			return nil, err
+		}
+	default:
+		logrus.Errorf("%s is not a valid value for filelist list format.", c.ListFormat)
+		err = constants.ErrEndpointInvalid
+		return
+	}
+
 	return
 }
+
+// load will load metadata in structured list.
+func (c *Client) load() (err error) {
+	f, err := os.Open(c.ListPath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	c.entries = make(map[string]*entry)
+
+	p := newParser(c.ListFormat)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		e, err := p.parse(s.Text())
+		if err != nil {
+			logrus.Errorf("Filelist parse line %s failed for %v.", s.Text(), err)
+			return err
+		}
+		if e == nil {
+			continue
+		}
+		c.entries[cleanKey(e.Key)] = e
+	}
+	return s.Err()
+}
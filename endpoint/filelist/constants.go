@@ -0,0 +1,21 @@
+package filelist
+
+// List format related constants.
+const (
+	// ListFormatPlain means every line is a key.
+	ListFormatPlain = "plain"
+	// ListFormatCSV means the list is a csv file whose first line is the
+	// header, available columns are key, size, mtime and md5.
+	ListFormatCSV = "csv"
+	// ListFormatJSONL means every line is a json object, available fields
+	// are key, size, mtime and md5.
+	ListFormatJSONL = "jsonl"
+)
+
+// CSV columns.
+const (
+	ColumnKey          = "key"
+	ColumnSize         = "size"
+	ColumnLastModified = "mtime"
+	ColumnMD5          = "md5"
+)
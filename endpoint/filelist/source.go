@@ -37,15 +37,45 @@ func (c *Client) List(ctx context.Context, j *model.DirectoryObject, fn func(o m
 	defer fi.Close()
 
 	buf := bufio.NewScanner(fi)
+	p := newParser(c.ListFormat)
 
 	for buf.Scan() {
 		line := buf.Text()
 
 		cur += int64(len(buf.Bytes()))
 
-		// TODO: we should get file's size here.
+		e, err := p.parse(line)
+		if err != nil {
+			logrus.Errorf("Filelist parse line %s failed for %v.", line, err)
+			return err
+		}
+		if e == nil {
+			continue
+		}
+
 		o := &model.SingleObject{
-			Key: "/" + utils.Join(j.Key, line),
+			Key: "/" + utils.Join(j.Key, e.Key),
+			MD5: e.MD5,
+		}
+
+		// Stat the file only if size or mtime is missing in the list.
+		if e.Size == nil || e.LastModified == nil {
+			so, err := c.Stat(ctx, o.Key, false)
+			if err != nil {
+				logrus.Errorf("Filelist stat %s failed for %v.", o.Key, err)
+				return err
+			}
+			if so == nil {
+				logrus.Warnf("Filelist object %s is not found, skipped.", o.Key)
+				continue
+			}
+			o.Size, o.LastModified = so.Size, so.LastModified
+		}
+		if e.Size != nil {
+			o.Size = *e.Size
+		}
+		if e.LastModified != nil {
+			o.LastModified = *e.LastModified
 		}
 
 		fn(o)
@@ -69,3 +99,13 @@ func (c *Client) Reach(ctx context.Context, p string) (url string, err error) {
 func (c *Client) Reachable() bool {
 	return false
 }
+
+// Delete implement source.Delete
+func (c *Client) Delete(ctx context.Context, p string) (err error) {
+	return constants.ErrEndpointFuncNotImplemented
+}
+
+// Deletable implement source.Deletable
+func (c *Client) Deletable() bool {
+	return false
+}
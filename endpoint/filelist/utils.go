@@ -0,0 +1,120 @@
+package filelist
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// entry is a line in the list, nil fields will be stated lazily.
+type entry struct {
+	Key          string `json:"key"`
+	Size         *int64 `json:"size"`
+	LastModified *int64 `json:"mtime"`
+	MD5          string `json:"md5"`
+}
+
+// parser will parse lines in the list into entries.
+type parser struct {
+	format string
+	// header stores csv column indexes.
+	header map[string]int
+}
+
+func newParser(format string) *parser {
+	return &parser{format: format}
+}
+
+// parse will parse a line, nil entry will be returned for empty line and
+// csv header.
+func (p *parser) parse(line string) (e *entry, err error) {
+	switch p.format {
+	case ListFormatCSV:
+		return p.parseCSV(line)
+	case ListFormatJSONL:
+		if strings.TrimSpace(line) == "" {
+			return nil, nil
+		}
+		e = &entry{}
+		err = json.Unmarshal([]byte(line), e)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		if line == "" {
+			return nil, nil
+		}
+		e = &entry{Key: line}
+	}
+
+	if e.Key == "" {
+		return nil, fmt.Errorf("key is empty in line %q", line)
+	}
+	return
+}
+
+func (p *parser) parseCSV(line string) (e *entry, err error) {
+	if strings.TrimSpace(line) == "" {
+		return nil, nil
+	}
+
+	records, err := csv.NewReader(strings.NewReader(line)).Read()
+	if err != nil {
+		return
+	}
+
+	// First line is the header.
+	if p.header == nil {
+		p.header = make(map[string]int)
+		for k, v := range records {
+			p.header[strings.ToLower(strings.TrimSpace(v))] = k
+		}
+		if _, ok := p.header[ColumnKey]; !ok {
+			return nil, fmt.Errorf("column %s is missing in header %q", ColumnKey, line)
+		}
+		return nil, nil
+	}
+
+	column := func(name string) string {
+		i, ok := p.header[name]
+		if !ok || i >= len(records) {
+			return ""
+		}
+		return strings.TrimSpace(records[i])
+	}
+	number := func(name string) (*int64, error) {
+		v := column(name)
+		if v == "" {
+			return nil, nil
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &n, nil
+	}
+
+	e = &entry{
+		Key: column(ColumnKey),
+		MD5: column(ColumnMD5),
+	}
+	if e.Key == "" {
+		return nil, fmt.Errorf("key is empty in line %q", line)
+	}
+	if e.Size, err = number(ColumnSize); err != nil {
+		return nil, err
+	}
+	if e.LastModified, err = number(ColumnLastModified); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// cleanKey will clean the key so that keys in list and keys while stating
+// could be matched.
+func cleanKey(p string) string {
+	return path.Clean("/" + p)
+}
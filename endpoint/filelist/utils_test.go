@@ -0,0 +1,57 @@
+package filelist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+type parseCase struct {
+	line string
+	out  *entry
+	err  bool
+}
+
+func TestParser(t *testing.T) {
+	cases := map[string][]parseCase{
+		ListFormatPlain: {
+			{"a/b", &entry{Key: "a/b"}, false},
+			{"", nil, false},
+		},
+		ListFormatCSV: {
+			{"key,md5,size", nil, false},
+			{"a/b,abc,10", &entry{Key: "a/b", Size: int64Ptr(10), MD5: "abc"}, false},
+			{`"a,b",,`, &entry{Key: "a,b"}, false},
+			{",abc,10", nil, true},
+			{"a/b,abc,x", nil, true},
+		},
+		ListFormatJSONL: {
+			{`{"key":"a/b","size":10,"mtime":1600000000}`,
+				&entry{Key: "a/b", Size: int64Ptr(10), LastModified: int64Ptr(1600000000)}, false},
+			{`{"key":"a/b","md5":"abc"}`, &entry{Key: "a/b", MD5: "abc"}, false},
+			{" ", nil, false},
+			{`{"size":10}`, nil, true},
+			{`{"key":`, nil, true},
+		},
+	}
+
+	for format, v := range cases {
+		p := newParser(format)
+		for _, c := range v {
+			e, err := p.parse(c.line)
+			if c.err {
+				assert.Error(t, err, c.line)
+				continue
+			}
+			assert.NoError(t, err, c.line)
+			assert.Equal(t, c.out, e, c.line)
+		}
+	}
+
+	_, err := newParser(ListFormatCSV).parse("size,md5")
+	assert.Error(t, err, "csv header without key should be invalid")
+}
@@ -0,0 +1,109 @@
+package ftp
+
+import (
+	"context"
+	"io"
+	"net/textproto"
+	"path"
+
+	"github.com/jlaffaye/ftp"
+
+	"github.com/yunify/qscamel/model"
+)
+
+// Name implement base.Read
+func (c *Client) Name(ctx context.Context) (name string) {
+	return "ftp:" + c.Address
+}
+
+// Read implement source.Read
+func (c *Client) Read(ctx context.Context, p string, _ bool) (r io.Reader, err error) {
+	cp := path.Join(c.Path, p)
+
+	conn, err := c.acquire()
+	if err != nil {
+		return
+	}
+
+	size, err := conn.FileSize(cp)
+	if err != nil {
+		c.release(conn, err)
+		return
+	}
+
+	return c.retr(conn, cp, 0, size)
+}
+
+// ReadRange implement source.ReadRange
+func (c *Client) ReadRange(
+	ctx context.Context, p string, offset, size int64,
+) (r io.Reader, err error) {
+	cp := path.Join(c.Path, p)
+
+	conn, err := c.acquire()
+	if err != nil {
+		return
+	}
+
+	return c.retr(conn, cp, offset, size)
+}
+
+// retr will retrieve size bytes from offset via REST and RETR.
+func (c *Client) retr(conn *ftp.ServerConn, cp string, offset, size int64) (r io.Reader, err error) {
+	resp, err := conn.RetrFrom(cp, uint64(offset))
+	if err != nil {
+		c.release(conn, err)
+		return
+	}
+
+	return &fileReader{
+		resp: resp,
+		size: size,
+		release: func(err error) {
+			c.release(conn, err)
+		},
+	}, nil
+}
+
+// Stat implement source.Stat and destination.Stat
+func (c *Client) Stat(ctx context.Context, p string, _ bool) (o *model.SingleObject, err error) {
+	cp := path.Join(c.Path, p)
+
+	conn, err := c.acquire()
+	if err != nil {
+		return
+	}
+
+	size, err := conn.FileSize(cp)
+	if err != nil {
+		// Server replies 550 if file doesn't exist, the connection is
+		// still usable in this case.
+		if e, ok := err.(*textproto.Error); ok && e.Code == ftp.StatusFileUnavailable {
+			c.release(conn, nil)
+			return nil, nil
+		}
+		c.release(conn, err)
+		return
+	}
+
+	// Last modified can only be got via MDTM, it will be left empty if
+	// server doesn't support it.
+	var lastModified int64
+	if conn.IsGetTimeSupported() {
+		mt, err := conn.GetTime(cp)
+		if err != nil {
+			c.release(conn, err)
+			return nil, err
+		}
+		lastModified = mt.Unix()
+	}
+	c.release(conn, nil)
+
+	// We will not calculate md5 while stating object.
+	o = &model.SingleObject{
+		Key:          p,
+		Size:         size,
+		LastModified: lastModified,
+	}
+	return
+}
@@ -0,0 +1,159 @@
+package ftp
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"github.com/jlaffaye/ftp"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/model"
+)
+
+// Client is the struct for FTP endpoint.
+type Client struct {
+	Address  string `yaml:"address"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+
+	// Whether to use explicit TLS (FTPES).
+	ExplicitTLS        bool `yaml:"explicit_tls"`
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
+	// Use PASV instead of EPSV for passive mode.
+	DisableEPSV bool `yaml:"disable_epsv"`
+	// Use LIST instead of MLSD even if server supports it.
+	DisableMLSD bool `yaml:"disable_mlsd"`
+
+	MaxIdleConnections int `yaml:"max_idle_connections"`
+
+	Path string
+
+	// conns stores idle control connections, ftp control connection
+	// could only handle one transfer at the same time.
+	conns chan *ftp.ServerConn
+}
+
+// New will create a client.
+func New(ctx context.Context, et uint8, _ *http.Client) (c *Client, err error) {
+	t, err := model.GetTask(ctx)
+	if err != nil {
+		return
+	}
+
+	c = &Client{}
+
+	e := t.Src
+	if et == constants.DestinationEndpoint {
+		e = t.Dst
+	}
+
+	content, err := yaml.Marshal(e.Options)
+	if err != nil {
+		return
+	}
+	err = yaml.Unmarshal(content, c)
+	if err != nil {
+		return
+	}
+
+	// Check address.
+	if c.Address == "" {
+		logrus.Error("FTP's address can't be empty.")
+		err = constants.ErrEndpointInvalid
+		return
+	}
+	if _, _, err = net.SplitHostPort(c.Address); err != nil {
+		c.Address = net.JoinHostPort(c.Address, DefaultPort)
+	}
+	// Set user.
+	if c.User == "" {
+		c.User = DefaultUser
+	}
+	// Set max idle connections.
+	if c.MaxIdleConnections < 0 {
+		logrus.Error("FTP's max idle connections can't be negative.")
+		err = constants.ErrEndpointInvalid
+		return
+	}
+	if c.MaxIdleConnections == 0 {
+		c.MaxIdleConnections = DefaultMaxIdleConnections
+	}
+
+	c.Path = e.Path
+
+	c.init()
+
+	// Dial once to make sure the endpoint is valid.
+	conn, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	c.release(conn, nil)
+	return
+}
+
+// init will initialize the connection pool.
+func (c *Client) init() {
+	c.conns = make(chan *ftp.ServerConn, c.MaxIdleConnections)
+}
+
+// dial will create a new logged in control connection.
+func (c *Client) dial() (conn *ftp.ServerConn, err error) {
+	opts := []ftp.DialOption{
+		ftp.DialWithTimeout(DefaultDialTimeout),
+		ftp.DialWithDisabledEPSV(c.DisableEPSV),
+		ftp.DialWithDisabledMLSD(c.DisableMLSD),
+	}
+	if c.ExplicitTLS {
+		host, _, _ := net.SplitHostPort(c.Address)
+		opts = append(opts, ftp.DialWithExplicitTLS(&tls.Config{
+			ServerName:         host,
+			InsecureSkipVerify: c.InsecureSkipVerify,
+		}))
+	}
+
+	conn, err = ftp.Dial(c.Address, opts...)
+	if err != nil {
+		logrus.Errorf("FTP dial %s failed for %v.", c.Address, err)
+		return
+	}
+
+	err = conn.Login(c.User, c.Password)
+	if err != nil {
+		logrus.Errorf("FTP login %s failed for %v.", c.Address, err)
+		_ = conn.Quit()
+		return nil, err
+	}
+	return
+}
+
+// acquire will get an idle connection or dial a new one.
+func (c *Client) acquire() (conn *ftp.ServerConn, err error) {
+	select {
+	case conn = <-c.conns:
+		return conn, nil
+	default:
+	}
+
+	return c.dial()
+}
+
+// release will put the connection back, connection with error will be
+// closed because we don't know its state any more.
+func (c *Client) release(conn *ftp.ServerConn, err error) {
+	if err != nil {
+		_ = conn.Quit()
+		return
+	}
+
+	select {
+	case c.conns <- conn:
+	default:
+		// Too many idle connections.
+		_ = conn.Quit()
+	}
+}
@@ -0,0 +1,405 @@
+package ftp
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yunify/qscamel/model"
+)
+
+const (
+	testUser     = "qscamel"
+	testPassword = "password"
+)
+
+// testServer is a minimal in-process ftp server which serves files on
+// the local file system.
+type testServer struct {
+	addr string
+	mlsd bool
+	tls  *tls.Config
+
+	l net.Listener
+}
+
+func newTestServer(t *testing.T, mlsd bool) *testServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	s := &testServer{
+		addr: l.Addr().String(),
+		mlsd: mlsd,
+		tls:  newTestTLSConfig(t),
+		l:    l,
+	}
+	go s.serve()
+	return s
+}
+
+func newTestTLSConfig(t *testing.T) *tls.Config {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}
+}
+
+func (s *testServer) serve() {
+	for {
+		conn, err := s.l.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *testServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(format string, args ...interface{}) {
+		_, _ = fmt.Fprintf(conn, format+"\r\n", args...)
+	}
+
+	var (
+		user   string
+		prot   bool
+		offset int64
+		data   chan net.Conn
+	)
+	// accept will accept the passive data connection in background, tls
+	// handshake will be done at once as real servers do.
+	accept := func(l net.Listener, prot bool) chan net.Conn {
+		ch := make(chan net.Conn, 1)
+		go func() {
+			defer close(ch)
+			defer l.Close()
+
+			dc, err := l.Accept()
+			if err != nil {
+				return
+			}
+			if prot {
+				tc := tls.Server(dc, s.tls)
+				if tc.Handshake() != nil {
+					_ = dc.Close()
+					return
+				}
+				dc = tc
+			}
+			ch <- dc
+		}()
+		return ch
+	}
+	// open will return the passive data connection.
+	open := func() (net.Conn, error) {
+		if data == nil {
+			return nil, io.ErrClosedPipe
+		}
+		defer func() {
+			data = nil
+		}()
+
+		dc, ok := <-data
+		if !ok {
+			return nil, io.ErrClosedPipe
+		}
+		return dc, nil
+	}
+
+	reply("220 ready")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd, arg := line, ""
+		if i := strings.Index(line, " "); i > 0 {
+			cmd, arg = line[:i], line[i+1:]
+		}
+
+		switch strings.ToUpper(cmd) {
+		case "AUTH":
+			reply("234 ok")
+			conn = tls.Server(conn, s.tls)
+			r = bufio.NewReader(conn)
+		case "USER":
+			user = arg
+			reply("331 password required")
+		case "PASS":
+			if user != testUser || arg != testPassword {
+				reply("530 login incorrect")
+				continue
+			}
+			reply("230 logged in")
+		case "FEAT":
+			if s.mlsd {
+				reply("211-Features:\r\n MDTM\r\n MLST type*;size*;modify*;\r\n UTF8\r\n211 End")
+			} else {
+				reply("211-Features:\r\n MDTM\r\n UTF8\r\n211 End")
+			}
+		case "TYPE", "OPTS", "PBSZ":
+			reply("200 ok")
+		case "PROT":
+			prot = arg == "P"
+			reply("200 ok")
+		case "EPSV":
+			l, _ := net.Listen("tcp", "127.0.0.1:0")
+			data = accept(l, prot)
+			reply("229 Entering Extended Passive Mode (|||%d|)", l.Addr().(*net.TCPAddr).Port)
+		case "SIZE":
+			fi, err := os.Stat(arg)
+			if err != nil || fi.IsDir() {
+				reply("550 not found")
+				continue
+			}
+			reply("213 %d", fi.Size())
+		case "MDTM":
+			fi, err := os.Stat(arg)
+			if err != nil || fi.IsDir() {
+				reply("550 not found")
+				continue
+			}
+			reply("213 %s", fi.ModTime().UTC().Format("20060102150405"))
+		case "REST":
+			offset, _ = strconv.ParseInt(arg, 10, 64)
+			reply("350 restarting")
+		case "RETR":
+			f, err := os.Open(arg)
+			if err != nil {
+				reply("550 not found")
+				continue
+			}
+			_, _ = f.Seek(offset, io.SeekStart)
+			offset = 0
+			reply("150 opening data connection")
+			dc, err := open()
+			if err != nil {
+				_ = f.Close()
+				reply("425 can't open data connection")
+				continue
+			}
+			_, err = io.Copy(dc, f)
+			_ = f.Close()
+			_ = dc.Close()
+			if err != nil {
+				reply("426 transfer aborted")
+				continue
+			}
+			reply("226 transfer complete")
+		case "MLSD", "LIST":
+			fis, err := ioutil.ReadDir(arg)
+			if err != nil {
+				reply("550 not found")
+				continue
+			}
+			reply("150 opening data connection")
+			dc, err := open()
+			if err != nil {
+				reply("425 can't open data connection")
+				continue
+			}
+			for _, fi := range fis {
+				_, _ = io.WriteString(dc, formatEntry(strings.ToUpper(cmd), fi)+"\r\n")
+			}
+			_ = dc.Close()
+			reply("226 transfer complete")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func formatEntry(cmd string, fi os.FileInfo) string {
+	if cmd == "MLSD" {
+		typ := "file"
+		if fi.IsDir() {
+			typ = "dir"
+		}
+		return fmt.Sprintf("type=%s;size=%d;modify=%s; %s",
+			typ, fi.Size(), fi.ModTime().UTC().Format("20060102150405"), fi.Name())
+	}
+
+	mode := "-rw-r--r--"
+	if fi.IsDir() {
+		mode = "drwxr-xr-x"
+	} else if fi.Mode()&os.ModeSymlink != 0 {
+		mode = "lrwxrwxrwx"
+	}
+	return fmt.Sprintf("%s 1 owner group %d %s %s",
+		mode, fi.Size(), fi.ModTime().UTC().Format("Jan _2  2006"), fi.Name())
+}
+
+func (s *testServer) Close() {
+	_ = s.l.Close()
+}
+
+func newTestClient(t *testing.T, mlsd, explicitTLS bool) (*Client, func()) {
+	dir, err := ioutil.TempDir("", "qscamel-ftp")
+	assert.NoError(t, err)
+
+	s := newTestServer(t, mlsd)
+
+	c := &Client{
+		Address:            s.addr,
+		User:               testUser,
+		Password:           testPassword,
+		ExplicitTLS:        explicitTLS,
+		InsecureSkipVerify: true,
+		MaxIdleConnections: 2,
+		Path:               dir,
+	}
+	c.init()
+
+	return c, func() {
+		s.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestClient_List(t *testing.T) {
+	cases := []struct {
+		name        string
+		mlsd        bool
+		explicitTLS bool
+	}{
+		{"mlsd", true, false},
+		{"list", false, false},
+		{"explicit tls", true, true},
+	}
+
+	for _, v := range cases {
+		t.Run(v.name, func(t *testing.T) {
+			c, closer := newTestClient(t, v.mlsd, v.explicitTLS)
+			defer closer()
+
+			mtime := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+			assert.NoError(t, os.MkdirAll(filepath.Join(c.Path, "a", "b"), 0755))
+			assert.NoError(t, ioutil.WriteFile(filepath.Join(c.Path, "a", "x.txt"), []byte("x"), 0644))
+			assert.NoError(t, os.Chtimes(filepath.Join(c.Path, "a", "x.txt"), mtime, mtime))
+
+			var dirs []string
+			var files []*model.SingleObject
+			err := c.List(context.Background(), &model.DirectoryObject{Key: "/a"}, func(o model.Object) {
+				switch x := o.(type) {
+				case *model.DirectoryObject:
+					dirs = append(dirs, x.Key)
+				case *model.SingleObject:
+					files = append(files, x)
+				}
+			})
+			assert.NoError(t, err)
+			sort.Strings(dirs)
+			assert.Equal(t, []string{"/a/b"}, dirs)
+			assert.Len(t, files, 1)
+			assert.Equal(t, "/a/x.txt", files[0].Key)
+			assert.Equal(t, int64(1), files[0].Size)
+			assert.Equal(t, mtime.Unix(), files[0].LastModified)
+		})
+	}
+}
+
+func TestClient_Read(t *testing.T) {
+	c, closer := newTestClient(t, true, false)
+	defer closer()
+
+	ctx := context.Background()
+	content := []byte("0123456789")
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(c.Path, "r.txt"), content, 0644))
+
+	r, err := c.Read(ctx, "/r.txt", false)
+	assert.NoError(t, err)
+	got, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, content, got)
+
+	r, err = c.ReadRange(ctx, "/r.txt", 3, 4)
+	assert.NoError(t, err)
+	got, err = ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, content[3:7], got)
+
+	// Connection should be released after read finished.
+	assert.NotEmpty(t, c.conns)
+
+	// Reader closed before read finished should not block, and client
+	// should still work.
+	large := make([]byte, 8<<20)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(c.Path, "large.bin"), large, 0644))
+	r, err = c.Read(ctx, "/large.bin", false)
+	assert.NoError(t, err)
+	_, err = io.ReadFull(r, make([]byte, 10))
+	assert.NoError(t, err)
+	closed := make(chan struct{})
+	go func() {
+		_ = r.(io.Closer).Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("close should not block while reading")
+	}
+	assert.NoError(t, r.(io.Closer).Close())
+
+	r, err = c.Read(ctx, "/r.txt", false)
+	assert.NoError(t, err)
+	got, err = ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, content, got)
+
+	mt := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, os.Chtimes(filepath.Join(c.Path, "r.txt"), mt, mt))
+
+	o, err := c.Stat(ctx, "/r.txt", false)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(content)), o.Size)
+	assert.Equal(t, mt.Unix(), o.LastModified)
+
+	o, err = c.Stat(ctx, "/not-exist", false)
+	assert.NoError(t, err)
+	assert.Nil(t, o)
+}
+
+func TestClient_Login(t *testing.T) {
+	c, closer := newTestClient(t, true, false)
+	defer closer()
+
+	c.Password = "wrong"
+	_, err := c.acquire()
+	assert.Error(t, err)
+}
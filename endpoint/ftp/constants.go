@@ -0,0 +1,15 @@
+package ftp
+
+import "time"
+
+// Connection related constants.
+const (
+	// DefaultPort is the default ftp port.
+	DefaultPort = "21"
+	// DefaultUser is the user used while user is not set.
+	DefaultUser = "anonymous"
+	// DefaultMaxIdleConnections is the default max idle control connections.
+	DefaultMaxIdleConnections = 10
+	// DefaultDialTimeout is the default timeout for dial.
+	DefaultDialTimeout = 30 * time.Second
+)
@@ -0,0 +1,81 @@
+package ftp
+
+import (
+	"context"
+	"path"
+
+	"github.com/jlaffaye/ftp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// List implement source.List
+//
+// Entries will be listed via MLSD if server supports it, or LIST will be
+// used and parsed in unix, dos and other common formats.
+func (c *Client) List(ctx context.Context, j *model.DirectoryObject, fn func(o model.Object)) (err error) {
+	cp := path.Join(c.Path, j.Key)
+
+	conn, err := c.acquire()
+	if err != nil {
+		return
+	}
+
+	list, err := conn.List(cp)
+	c.release(conn, err)
+	if err != nil {
+		logrus.Warnf("FTP list <%s> failed: [%v]", cp, err)
+		return
+	}
+
+	for _, v := range list {
+		if v.Name == "." || v.Name == ".." {
+			continue
+		}
+
+		switch v.Type {
+		case ftp.EntryTypeFolder:
+			o := &model.DirectoryObject{
+				Key: "/" + utils.Join(j.Key, v.Name),
+			}
+
+			fn(o)
+		case ftp.EntryTypeFile:
+			o := &model.SingleObject{
+				Key:          "/" + utils.Join(j.Key, v.Name),
+				Size:         int64(v.Size),
+				LastModified: v.Time.Unix(),
+			}
+
+			fn(o)
+		default:
+			logrus.Infof("target <%s> skipped because its not a regular file",
+				path.Join(cp, v.Name))
+		}
+	}
+
+	return
+}
+
+// Reach implement source.Fetch
+func (c *Client) Reach(ctx context.Context, p string) (url string, err error) {
+	return "", constants.ErrEndpointFuncNotImplemented
+}
+
+// Reachable implement source.Reachable
+func (c *Client) Reachable() bool {
+	return false
+}
+
+// Delete implement source.Delete
+func (c *Client) Delete(ctx context.Context, p string) (err error) {
+	return constants.ErrEndpointFuncNotImplemented
+}
+
+// Deletable implement source.Deletable
+func (c *Client) Deletable() bool {
+	return false
+}
@@ -0,0 +1,55 @@
+package ftp
+
+import (
+	"io"
+	"sync"
+
+	"github.com/jlaffaye/ftp"
+)
+
+// fileReader will release the control connection after read finished or
+// closed.
+//
+// Destination may stop reading right after size bytes without hitting
+// EOF, so we need to track the remaining size. Reader will also be closed
+// by migrate while destination is still reading in another goroutine, so
+// the connection must be released only once.
+type fileReader struct {
+	resp *ftp.Response
+	size int64
+
+	once    sync.Once
+	release func(err error)
+}
+
+// Read implement io.Reader
+func (f *fileReader) Read(p []byte) (n int, err error) {
+	if f.size <= 0 {
+		_ = f.Close()
+		return 0, io.EOF
+	}
+	if int64(len(p)) > f.size {
+		p = p[:f.size]
+	}
+
+	n, err = f.resp.Read(p)
+	f.size -= int64(n)
+	if err == io.EOF && f.size > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil || f.size <= 0 {
+		_ = f.Close()
+	}
+	return
+}
+
+// Close implement io.Closer
+func (f *fileReader) Close() (err error) {
+	f.once.Do(func() {
+		// Server will reply transfer complete only if all data has been
+		// sent, connection closed before that will be dropped.
+		err = f.resp.Close()
+		f.release(err)
+	})
+	return
+}
@@ -2,25 +2,38 @@ package gcs
 
 import (
 	"context"
+	"io/ioutil"
 	"net/http"
 
 	"cloud.google.com/go/storage"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"golang.org/x/oauth2/jwt"
 	"google.golang.org/api/option"
 	"gopkg.in/yaml.v2"
 
 	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
 )
 
 // Client is the client to visit service.
 type Client struct {
-	APIKey     string `yaml:"api_key"`
-	BucketName string `yaml:"bucket_name"`
+	APIKey          string `yaml:"api_key"`
+	CredentialsFile string `yaml:"credentials_file"`
+	BucketName      string `yaml:"bucket_name"`
+
+	// Whether to migrate custom metadata
+	UserDefineMeta bool `yaml:"user_define_meta"`
+	// PresignExpire is the expire seconds of presigned url used in fetch.
+	PresignExpire int64 `yaml:"presign_expire"`
 
 	Path string
 
 	client *storage.BucketHandle
+	// jwt stores the service account used to sign url.
+	jwt *jwt.Config
 }
 
 // New will create a new client.
@@ -52,22 +65,78 @@ func New(ctx context.Context, et uint8, hc *http.Client) (c *Client, err error)
 		err = constants.ErrEndpointInvalid
 		return
 	}
-	// Set api key
-	if c.APIKey == "" {
-		logrus.Error("Google cloud storage API key can't be empty.")
+	// Set api key or credentials file.
+	// API key could only be used to read public data, credentials file is
+	// required while using as destination.
+	if c.APIKey == "" && c.CredentialsFile == "" {
+		logrus.Error("Google cloud storage API key and credentials file can't be both empty.")
+		err = constants.ErrEndpointInvalid
+		return
+	}
+	if et == constants.DestinationEndpoint && c.CredentialsFile == "" {
+		logrus.Error("Google cloud storage credentials file can't be empty for destination.")
 		err = constants.ErrEndpointInvalid
 		return
 	}
 
+	// Set presign expire.
+	c.PresignExpire, err = utils.CheckPresignExpire("Google cloud storage", c.PresignExpire, DefaultPresignExpire)
+	if err != nil {
+		return
+	}
+
 	// Set path.
 	c.Path = e.Path
 
-	svc, err := storage.NewClient(ctx,
-		option.WithAPIKey(c.APIKey),
-		option.WithHTTPClient(hc))
+	hc, err = c.newHTTPClient(hc)
+	if err != nil {
+		return
+	}
+	svc, err := storage.NewClient(ctx, option.WithHTTPClient(hc))
 	if err != nil {
 		return
 	}
 	c.client = svc.Bucket(c.BucketName)
 	return
 }
+
+// newHTTPClient will create a http client based on hc which authorizes
+// requests with api key or credentials file. Other auth options will be
+// ignored by storage client while a http client is set, so we need to do
+// it by ourselves.
+func (c *Client) newHTTPClient(hc *http.Client) (x *http.Client, err error) {
+	x = &http.Client{}
+	if hc != nil {
+		*x = *hc
+	}
+
+	if c.CredentialsFile == "" {
+		x.Transport = &apiKeyTransport{key: c.APIKey, base: x.Transport}
+		return
+	}
+
+	content, err := ioutil.ReadFile(c.CredentialsFile)
+	if err != nil {
+		return
+	}
+	// Token will be refreshed during the whole task, so it should not be
+	// bound to the ctx of current call.
+	tctx := context.Background()
+	if hc != nil {
+		tctx = context.WithValue(tctx, oauth2.HTTPClient, hc)
+	}
+	creds, err := google.CredentialsFromJSON(tctx, content, storage.ScopeFullControl)
+	if err != nil {
+		logrus.Errorf("Google cloud storage parse credentials file failed for %v.", err)
+		return
+	}
+	x.Transport = &oauth2.Transport{Source: creds.TokenSource, Base: x.Transport}
+
+	// Load service account for signing url.
+	c.jwt, err = google.JWTConfigFromJSON(content)
+	if err != nil {
+		logrus.Errorf("Google cloud storage parse credentials file failed for %v.", err)
+		return
+	}
+	return
+}
@@ -0,0 +1,29 @@
+package gcs
+
+// Multipart related constants.
+// ref: https://cloud.google.com/storage/docs/composite-objects
+const (
+	// DefaultMultipartSize is the default part size.
+	// 64 * 1024 * 1024 = 67108864 B = 64 MB
+	DefaultMultipartSize = 67108864
+	// MaxMultipartNumber is the max part number that we will upload.
+	MaxMultipartNumber = 10000
+	// MaxMultipartBoundarySize is the max part size.
+	// 5 * 1024 * 1024 * 1024 = 5368709120 B = 5 GB
+	MaxMultipartBoundarySize = 5368709120
+	// MaxComposeComponents is the max source objects in one compose request.
+	MaxComposeComponents = 32
+	// PartSuffix is the suffix of the temporary directory which stores
+	// parts of an object.
+	PartSuffix = ".qscamel-parts"
+)
+
+// Write related constants.
+const (
+	// WriteChunkSize is the chunk size used by resumable upload.
+	// 16 * 1024 * 1024 = 16777216 B = 16 MB
+	WriteChunkSize = 16777216
+)
+
+// DefaultPresignExpire is the default expire seconds for presigned url.
+const DefaultPresignExpire = 3600
@@ -0,0 +1,256 @@
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/iterator"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// Deletable implement destination.Deletable
+func (c *Client) Deletable() bool {
+	return true
+}
+
+// Fetchable implement destination.Fetchable
+func (c *Client) Fetchable() bool {
+	return false
+}
+
+// Writable implement destination.Writable
+func (c *Client) Writable() bool {
+	return true
+}
+
+// Delete implement destination.Delete
+func (c *Client) Delete(ctx context.Context, p string) (err error) {
+	cp := utils.Join(c.Path, p)
+
+	err = c.client.Object(cp).Delete(ctx)
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("GCS delete object %s.", cp)
+	return
+}
+
+// Write implement destination.Write
+func (c *Client) Write(ctx context.Context, p string, size int64, r io.Reader, isDir bool, meta map[string]string) (err error) {
+	cp := utils.Join(c.Path, p)
+
+	if isDir {
+		// Directory object is an empty object whose key ends with "/".
+		if !strings.HasSuffix(cp, "/") {
+			cp += "/"
+		}
+		r = bytes.NewReader(nil)
+		size = 0
+	}
+
+	w := c.client.Object(cp).NewWriter(ctx)
+	// Writer with non-zero chunk size will use resumable upload.
+	w.ChunkSize = WriteChunkSize
+	w.ObjectAttrs = c.objectAttrs(meta)
+	w.Name = cp
+
+	// wrap by limitReader to keep body consistent with size
+	_, err = io.Copy(w, io.LimitReader(r, size))
+	if err != nil {
+		_ = w.CloseWithError(err)
+		return
+	}
+	err = w.Close()
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("GCS wrote object %s.", cp)
+	return
+}
+
+// Fetch implement destination.Fetch
+func (c *Client) Fetch(ctx context.Context, p, url string) (err error) {
+	return constants.ErrEndpointFuncNotImplemented
+}
+
+// Partable implement destination.Partable
+func (c *Client) Partable() bool {
+	return true
+}
+
+// InitPart implement destination.InitPart
+//
+// GCS doesn't support multipart upload, we upload every part as a
+// temporary object and compose them in CompleteParts.
+// ref: https://cloud.google.com/storage/docs/composite-objects
+func (c *Client) InitPart(ctx context.Context, p string, size int64, meta map[string]string) (uploadID string, partSize int64, partNumbers int, err error) {
+	uploadID, err = newUploadID()
+	if err != nil {
+		return
+	}
+
+	partSize, err = calculatePartSize(size)
+	if err != nil {
+		logrus.Errorf("Object %s is too large", p)
+		return
+	}
+
+	partNumbers = int(size / partSize)
+	if size%partSize != 0 {
+		partNumbers++
+	}
+
+	// Object attrs can only be set while composing, store them so that the
+	// upload can be completed after qscamel restarted.
+	err = model.CreateUpload(ctx, &model.Upload{
+		ID:       uploadID,
+		Key:      p,
+		Metadata: meta,
+	})
+	return
+}
+
+// UploadPart implement destination.UploadPart
+func (c *Client) UploadPart(ctx context.Context, o *model.PartialObject, r io.Reader) (err error) {
+	cp := utils.Join(c.Path, o.Key)
+
+	w := c.client.Object(formatPartKey(cp, o.UploadID, o.PartNumber)).NewWriter(ctx)
+	w.ChunkSize = WriteChunkSize
+
+	// wrap by limitReader to keep body consistent with size
+	_, err = io.Copy(w, io.LimitReader(r, o.Size))
+	if err != nil {
+		_ = w.CloseWithError(err)
+		return
+	}
+	err = w.Close()
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("GCS wrote partial object %s at %d.", o.Key, o.Offset)
+	return nil
+}
+
+// CompleteParts implement destination.CompleteParts
+func (c *Client) CompleteParts(ctx context.Context, path string, uploadId string, totalNumber int) (err error) {
+	cp := utils.Join(c.Path, path)
+
+	logrus.Infof("Object %s start completing part", path)
+
+	srcs := make([]*storage.ObjectHandle, totalNumber)
+	for i := 0; i < totalNumber; i++ {
+		srcs[i] = c.client.Object(formatPartKey(cp, uploadId, i))
+	}
+
+	// A compose request could only have limited source objects, so we
+	// need to compose parts into intermediate objects level by level.
+	for level := 0; len(srcs) > MaxComposeComponents; level++ {
+		next := make([]*storage.ObjectHandle, 0, len(srcs)/MaxComposeComponents+1)
+		for i := 0; i < len(srcs); i += MaxComposeComponents {
+			end := i + MaxComposeComponents
+			if end > len(srcs) {
+				end = len(srcs)
+			}
+
+			dst := c.client.Object(formatComposeKey(cp, uploadId, level, len(next)))
+			_, err = dst.ComposerFrom(srcs[i:end]...).Run(ctx)
+			if err != nil {
+				return
+			}
+			next = append(next, dst)
+		}
+		srcs = next
+	}
+
+	u, err := model.GetUpload(ctx, uploadId)
+	if err != nil {
+		return
+	}
+	attrs := storage.ObjectAttrs{}
+	if u != nil {
+		attrs = c.objectAttrs(u.Metadata)
+	}
+
+	composer := c.client.Object(cp).ComposerFrom(srcs...)
+	composer.ObjectAttrs = attrs
+	_, err = composer.Run(ctx)
+	if err != nil {
+		return
+	}
+
+	err = model.DeleteUpload(ctx, uploadId)
+	if err != nil {
+		return
+	}
+
+	// Composed object is independent of its sources, failing to delete
+	// temporary objects should not fail the upload.
+	err = c.deleteParts(ctx, cp, uploadId)
+	if err != nil {
+		logrus.Warnf("Object %s delete temporary parts failed for %v", path, err)
+	}
+	return nil
+}
+
+// AbortUploads implement destination.AbortUploads
+func (c *Client) AbortUploads(ctx context.Context, path string, uploadId string) (err error) {
+	cp := utils.Join(c.Path, path)
+
+	logrus.Infof("Object %s start abort part", path)
+
+	err = model.DeleteUpload(ctx, uploadId)
+	if err != nil {
+		return
+	}
+
+	return c.deleteParts(ctx, cp, uploadId)
+}
+
+// deleteParts will delete all temporary objects of an upload.
+func (c *Client) deleteParts(ctx context.Context, cp, uploadID string) (err error) {
+	it := c.client.Objects(ctx, &storage.Query{
+		Prefix: formatPartPrefix(cp, uploadID),
+	})
+	for {
+		next, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		err = c.client.Object(next.Name).Delete(ctx)
+		if err != nil && err != storage.ErrObjectNotExist {
+			return err
+		}
+	}
+	return nil
+}
+
+// objectAttrs will convert object metadata into gcs object attrs.
+func (c *Client) objectAttrs(meta map[string]string) (attrs storage.ObjectAttrs) {
+	for k, v := range meta {
+		if k == "ContentType" {
+			attrs.ContentType = v
+			continue
+		}
+		if c.UserDefineMeta {
+			if attrs.Metadata == nil {
+				attrs.Metadata = make(map[string]string)
+			}
+			attrs.Metadata[utils.TrimQSMetaPrefix(k)] = v
+		}
+	}
+	return
+}
@@ -0,0 +1,313 @@
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/db"
+	"github.com/yunify/qscamel/model"
+)
+
+const testToken = "test-token"
+
+// fakeGCS is a minimal in-memory stand-in for the GCS JSON API used by
+// destination.
+type fakeGCS struct {
+	sync.Mutex
+
+	objects map[string][]byte
+	attrs   map[string]map[string]interface{}
+	// unauthorized counts requests without the expected token.
+	unauthorized int
+}
+
+func newFakeGCS() *fakeGCS {
+	return &fakeGCS{
+		objects: make(map[string][]byte),
+		attrs:   make(map[string]map[string]interface{}),
+	}
+}
+
+func (f *fakeGCS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	if r.URL.Path == "/token" {
+		writeJSON(w, map[string]interface{}{
+			"access_token": testToken,
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+testToken {
+		f.unauthorized++
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/upload/storage/v1/b/bucket/o":
+		attrs, content := readMultipart(r)
+		name := attrs["name"].(string)
+		f.objects[name] = content
+		f.attrs[name] = attrs
+		writeJSON(w, f.object(name))
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/compose"):
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/storage/v1/b/bucket/o/"), "/compose")
+		req := struct {
+			Destination   map[string]interface{} `json:"destination"`
+			SourceObjects []struct {
+				Name string `json:"name"`
+			} `json:"sourceObjects"`
+		}{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		buf := &bytes.Buffer{}
+		for _, v := range req.SourceObjects {
+			content, ok := f.objects[v.Name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			buf.Write(content)
+		}
+		f.objects[name] = buf.Bytes()
+		f.attrs[name] = req.Destination
+		writeJSON(w, f.object(name))
+	case r.Method == http.MethodGet && r.URL.Path == "/storage/v1/b/bucket/o":
+		prefix := r.URL.Query().Get("prefix")
+		names := make([]string, 0)
+		for k := range f.objects {
+			if strings.HasPrefix(k, prefix) {
+				names = append(names, k)
+			}
+		}
+		sort.Strings(names)
+		items := make([]interface{}, 0, len(names))
+		for _, v := range names {
+			items = append(items, f.object(v))
+		}
+		writeJSON(w, map[string]interface{}{"items": items})
+	case strings.HasPrefix(r.URL.Path, "/storage/v1/b/bucket/o/"):
+		name := strings.TrimPrefix(r.URL.Path, "/storage/v1/b/bucket/o/")
+		if _, ok := f.objects[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, f.object(name))
+		case http.MethodDelete:
+			delete(f.objects, name)
+			delete(f.attrs, name)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+// object will format the object resource of name.
+func (f *fakeGCS) object(name string) map[string]interface{} {
+	content := f.objects[name]
+	sum := md5.Sum(content)
+	o := map[string]interface{}{
+		"bucket":  "bucket",
+		"name":    name,
+		"size":    strconv.Itoa(len(content)),
+		"md5Hash": sum[:],
+		"updated": time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC).Format(time.RFC3339),
+	}
+	for k, v := range f.attrs[name] {
+		if k == "contentType" || k == "metadata" {
+			o[k] = v
+		}
+	}
+	return o
+}
+
+// readMultipart will read object attrs and content from a multipart upload.
+func readMultipart(r *http.Request) (attrs map[string]interface{}, content []byte) {
+	_, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	mr := multipart.NewReader(r.Body, params["boundary"])
+
+	p, _ := mr.NextPart()
+	_ = json.NewDecoder(p).Decode(&attrs)
+	p, _ = mr.NextPart()
+	content, _ = ioutil.ReadAll(p)
+	return
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeCredentialsFile will write a service account whose token is issued by
+// the fake server.
+func writeCredentialsFile(t *testing.T, dir, tokenURL string) string {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+
+	content, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "qscamel@example.com",
+		"private_key_id": "1",
+		"private_key": string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})),
+		"token_uri": tokenURL,
+	})
+	assert.NoError(t, err)
+
+	p := filepath.Join(dir, "credentials.json")
+	assert.NoError(t, ioutil.WriteFile(p, content, 0600))
+	return p
+}
+
+func newTestClient(t *testing.T, f *fakeGCS) (*Client, func()) {
+	srv := httptest.NewServer(f)
+
+	dir, err := ioutil.TempDir("", "qscamel-gcs")
+	assert.NoError(t, err)
+
+	// Uploads are stored in db.
+	d, err := db.NewDB(&db.DatabaseOptions{InMemory: true})
+	assert.NoError(t, err)
+	contexts.DB = d
+
+	c := &Client{
+		CredentialsFile: writeCredentialsFile(t, dir, srv.URL+"/token"),
+		BucketName:      "bucket",
+		UserDefineMeta:  true,
+		Path:            "prefix",
+	}
+	hc, err := c.newHTTPClient(http.DefaultClient)
+	assert.NoError(t, err)
+	svc, err := storage.NewClient(context.Background(),
+		option.WithHTTPClient(hc),
+		option.WithEndpoint(srv.URL+"/storage/v1/"))
+	assert.NoError(t, err)
+	c.client = svc.Bucket(c.BucketName)
+
+	return c, func() {
+		srv.Close()
+		d.Close()
+		contexts.DB = nil
+		os.RemoveAll(dir)
+	}
+}
+
+func TestClient_Write(t *testing.T) {
+	f := newFakeGCS()
+	c, closer := newTestClient(t, f)
+	defer closer()
+
+	ctx := context.Background()
+	content := []byte("hello, qscamel")
+
+	err := c.Write(ctx, "a/b.txt", int64(len(content)), bytes.NewReader(content), false,
+		map[string]string{"ContentType": "text/plain", "x-qs-meta-owner": "qscamel"})
+	assert.NoError(t, err)
+	assert.Equal(t, content, f.objects["prefix/a/b.txt"])
+	assert.Equal(t, "text/plain", f.attrs["prefix/a/b.txt"]["contentType"])
+	assert.Equal(t, map[string]interface{}{"owner": "qscamel"}, f.attrs["prefix/a/b.txt"]["metadata"])
+
+	o, err := c.Stat(ctx, "a/b.txt", false)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(content)), o.Size)
+
+	err = c.Write(ctx, "dir", 0, nil, true, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, f.objects, "prefix/dir/")
+
+	err = c.Delete(ctx, "a/b.txt")
+	assert.NoError(t, err)
+	o, err = c.Stat(ctx, "a/b.txt", false)
+	assert.NoError(t, err)
+	assert.Nil(t, o)
+
+	assert.Equal(t, 0, f.unauthorized)
+}
+
+func TestClient_Multipart(t *testing.T) {
+	f := newFakeGCS()
+	c, closer := newTestClient(t, f)
+	defer closer()
+
+	ctx := context.Background()
+	content := []byte("0123456789abcdefghij")
+
+	uploadID, partSize, partNumbers, err := c.InitPart(ctx, "large", int64(len(content)),
+		map[string]string{"ContentType": "text/plain"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(DefaultMultipartSize), partSize)
+	assert.Equal(t, 1, partNumbers)
+
+	// Upload in 3 parts out of order to make sure parts are composed in order.
+	size := int64(7)
+	for _, pn := range []int{2, 0, 1} {
+		offset := int64(pn) * size
+		end := offset + size
+		if end > int64(len(content)) {
+			end = int64(len(content))
+		}
+		err = c.UploadPart(ctx, &model.PartialObject{
+			Key:        "large",
+			Size:       end - offset,
+			Offset:     offset,
+			PartNumber: pn,
+			UploadID:   uploadID,
+		}, bytes.NewReader(content[offset:end]))
+		assert.NoError(t, err)
+	}
+
+	err = c.CompleteParts(ctx, "large", uploadID, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, content, f.objects["prefix/large"])
+	// Object attrs should be restored from db.
+	assert.Equal(t, "text/plain", f.attrs["prefix/large"]["contentType"])
+	// Temporary parts should be deleted.
+	assert.Len(t, f.objects, 1)
+
+	u, err := model.GetUpload(ctx, uploadID)
+	assert.NoError(t, err)
+	assert.Nil(t, u)
+
+	uploadID, _, _, err = c.InitPart(ctx, "aborted", 1, nil)
+	assert.NoError(t, err)
+	err = c.UploadPart(ctx, &model.PartialObject{
+		Key: "aborted", Size: 1, UploadID: uploadID,
+	}, bytes.NewReader([]byte("x")))
+	assert.NoError(t, err)
+	err = c.AbortUploads(ctx, "aborted", uploadID)
+	assert.NoError(t, err)
+	assert.Len(t, f.objects, 1)
+
+	assert.Equal(t, 0, f.unauthorized)
+}
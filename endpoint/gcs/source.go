@@ -2,7 +2,9 @@ package gcs
 
 import (
 	"context"
+	"net/http"
 	"path"
+	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/sirupsen/logrus"
@@ -52,10 +54,24 @@ func (c *Client) List(ctx context.Context, j *model.DirectoryObject, fn func(o m
 
 // Reach implement source.Fetch
 func (c *Client) Reach(ctx context.Context, p string) (url string, err error) {
-	return "", constants.ErrEndpointFuncNotImplemented
+	if !c.Reachable() {
+		return "", constants.ErrEndpointFuncNotImplemented
+	}
+
+	cp := utils.Join(c.Path, p)
+
+	return storage.SignedURL(c.BucketName, cp, &storage.SignedURLOptions{
+		GoogleAccessID: c.jwt.Email,
+		PrivateKey:     c.jwt.PrivateKey,
+		Method:         http.MethodGet,
+		Expires:        time.Now().Add(time.Duration(c.PresignExpire) * time.Second),
+		Scheme:         storage.SigningSchemeV4,
+	})
 }
 
 // Reachable implement source.Reachable
+//
+// Signing url requires a service account.
 func (c *Client) Reachable() bool {
-	return false
+	return c.jwt != nil
 }
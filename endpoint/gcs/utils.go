@@ -0,0 +1,77 @@
+package gcs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/yunify/qscamel/constants"
+)
+
+// calculatePartSize will calculate the object's part size.
+func calculatePartSize(size int64) (partSize int64, err error) {
+	partSize = DefaultMultipartSize
+
+	if size/partSize >= int64(MaxMultipartNumber) {
+		// Try to adjust partSize if it is too small and account for
+		// integer division truncation.
+		partSize = size/int64(MaxMultipartNumber) + 1
+	}
+
+	if partSize > MaxMultipartBoundarySize {
+		err = constants.ErrObjectTooLarge
+		return
+	}
+
+	return
+}
+
+// newUploadID will generate a random upload id, gcs doesn't have
+// upload id, we use it to separate parts of different uploads.
+func newUploadID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// formatPartPrefix will format the prefix of all temporary objects of
+// an upload.
+func formatPartPrefix(cp, uploadID string) string {
+	return fmt.Sprintf("%s%s/%s/", cp, PartSuffix, uploadID)
+}
+
+// formatPartKey will format the temporary object key for a part.
+func formatPartKey(cp, uploadID string, partNumber int) string {
+	return fmt.Sprintf("%spart-%05d", formatPartPrefix(cp, uploadID), partNumber)
+}
+
+// formatComposeKey will format the temporary object key for an
+// intermediate composed object.
+func formatComposeKey(cp, uploadID string, level, index int) string {
+	return fmt.Sprintf("%scompose-%d-%05d", formatPartPrefix(cp, uploadID), level, index)
+}
+
+// apiKeyTransport will set api key for every request.
+type apiKeyTransport struct {
+	key  string
+	base http.RoundTripper
+}
+
+// RoundTrip implement http.RoundTripper
+func (t *apiKeyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// RoundTripper should not modify the request.
+	x := r.Clone(r.Context())
+	q := x.URL.Query()
+	q.Set("key", t.key)
+	x.URL.RawQuery = q.Encode()
+	return base.RoundTrip(x)
+}
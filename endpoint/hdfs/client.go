@@ -16,6 +16,10 @@ import (
 type Client struct {
 	Address string `yaml:"address"`
 
+	// Replication and block size for written files.
+	Replication int   `yaml:"replication"`
+	BlockSize   int64 `yaml:"block_size"`
+
 	Path string
 
 	client *hdfs.Client
@@ -51,6 +55,19 @@ func New(ctx context.Context, et uint8, _ *http.Client) (c *Client, err error) {
 		return
 	}
 
+	// Set replication and block size.
+	if c.Replication < 0 || c.BlockSize < 0 {
+		logrus.Error("HDFS's replication and block size can't be negative.")
+		err = constants.ErrEndpointInvalid
+		return
+	}
+	if c.Replication == 0 {
+		c.Replication = DefaultReplication
+	}
+	if c.BlockSize == 0 {
+		c.BlockSize = DefaultBlockSize
+	}
+
 	c.Path = e.Path
 	c.client, err = hdfs.New(c.Address)
 	if err != nil {
@@ -0,0 +1,17 @@
+package hdfs
+
+// Write related constants.
+const (
+	// DefaultReplication is the default replication factor for written files.
+	DefaultReplication = 3
+	// DefaultBlockSize is the default block size for written files.
+	// 128 * 1024 * 1024 = 134217728 B = 128 MB
+	DefaultBlockSize = 134217728
+	// DefaultFileMode is the default permission for written files.
+	DefaultFileMode = 0644
+	// DefaultDirMode is the default permission for created directories.
+	DefaultDirMode = 0755
+	// TempFileSuffix is appended to the path of file being written, it will
+	// be renamed to the real path after written.
+	TempFileSuffix = ".qscamel.tmp"
+)
@@ -0,0 +1,131 @@
+package hdfs
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/model"
+)
+
+// Deletable implement destination.Deletable
+func (c *Client) Deletable() bool {
+	return true
+}
+
+// Fetchable implement destination.Fetchable
+func (c *Client) Fetchable() bool {
+	return false
+}
+
+// Writable implement destination.Writable
+func (c *Client) Writable() bool {
+	return true
+}
+
+// Delete implement destination.Delete
+func (c *Client) Delete(ctx context.Context, p string) (err error) {
+	cp := filepath.Join(c.Path, p)
+
+	err = c.client.Remove(cp)
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("HDFS delete file %s.", cp)
+	return
+}
+
+// Write implement destination.Write
+func (c *Client) Write(ctx context.Context, p string, size int64, r io.Reader, isDir bool, _ map[string]string) (err error) {
+	cp := filepath.Join(c.Path, p)
+
+	if isDir {
+		err = c.client.MkdirAll(cp, os.ModeDir|DefaultDirMode)
+		if err != nil {
+			return
+		}
+		logrus.Debugf("HDFS created dir %s.", cp)
+		return
+	}
+
+	err = c.client.MkdirAll(filepath.Dir(cp), os.ModeDir|DefaultDirMode)
+	if err != nil {
+		return
+	}
+
+	// Write into a temp file first, so that the existing file will be kept
+	// if write failed. Temp file left by last failed write should be
+	// removed, because HDFS can't overwrite an existing file.
+	tp := cp + TempFileSuffix
+	err = c.client.Remove(tp)
+	if err != nil && !os.IsNotExist(err) {
+		return
+	}
+
+	file, err := c.client.CreateFile(tp, c.Replication, c.BlockSize, DefaultFileMode)
+	if err != nil {
+		return
+	}
+
+	// wrap by limitReader to keep body consistent with size
+	_, err = io.Copy(file, io.LimitReader(r, size))
+	if err != nil {
+		_ = file.Close()
+		_ = c.client.Remove(tp)
+		return
+	}
+	// Data is buffered and acknowledged asynchronously, Close must be
+	// checked to make sure all data has been written.
+	err = file.Close()
+	if err != nil {
+		_ = c.client.Remove(tp)
+		return
+	}
+
+	// Rename will replace the existing file.
+	err = c.client.Rename(tp, cp)
+	if err != nil {
+		_ = c.client.Remove(tp)
+		return
+	}
+
+	logrus.Debugf("HDFS wrote file %s.", cp)
+	return
+}
+
+// Fetch implement destination.Fetch
+func (c *Client) Fetch(ctx context.Context, p, url string) (err error) {
+	return
+}
+
+// Partable implement destination.Partable
+//
+// HDFS could only append to a file sequentially, but parts will be
+// uploaded concurrently, so we don't support multipart.
+func (c *Client) Partable() bool {
+	return false
+}
+
+// InitPart implement destination.InitPart
+func (c *Client) InitPart(ctx context.Context, p string, size int64, _ map[string]string) (uploadID string, partSize int64, partNumbers int, err error) {
+	return "", 0, 0, nil
+}
+
+// UploadPart implement destination.UploadPart
+func (c *Client) UploadPart(ctx context.Context, o *model.PartialObject, r io.Reader) (err error) {
+	return nil
+}
+
+// CompleteParts implement destination.CompleteParts
+func (c *Client) CompleteParts(ctx context.Context, path string, uploadId string, totalNumber int) (err error) {
+	return nil
+}
+
+// AbortUploads implement destination.AbortUploads
+func (c *Client) AbortUploads(ctx context.Context, path string, uploadId string) (err error) {
+	return nil
+}
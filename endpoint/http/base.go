@@ -0,0 +1,101 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/yunify/qscamel/model"
+)
+
+// Name implement base.Read
+func (c *Client) Name(ctx context.Context) (name string) {
+	return "http:" + c.ListPath
+}
+
+// Read implement source.Read
+func (c *Client) Read(ctx context.Context, p string, _ bool) (r io.Reader, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, p)
+	if err != nil {
+		return
+	}
+
+	return c.do(req, http.StatusOK)
+}
+
+// ReadRange implement source.ReadRange
+func (c *Client) ReadRange(
+	ctx context.Context, p string, offset, size int64,
+) (r io.Reader, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, p)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+size-1))
+
+	// Server must support range request, or we will get the whole content.
+	return c.do(req, http.StatusPartialContent)
+}
+
+// Stat implement source.Stat and destination.Stat
+func (c *Client) Stat(ctx context.Context, p string, _ bool) (o *model.SingleObject, err error) {
+	req, err := c.newRequest(ctx, http.MethodHead, p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("head %s failed for status %s", req.URL, resp.Status)
+	}
+	// Content length is required to split object into parts.
+	if resp.ContentLength < 0 {
+		return nil, fmt.Errorf("head %s failed for unknown content length", req.URL)
+	}
+
+	// We will not calculate md5 while stating object.
+	o = &model.SingleObject{
+		Key:  p,
+		Size: resp.ContentLength,
+	}
+	if t, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		o.LastModified = t.Unix()
+	}
+	return
+}
+
+// newRequest will create a request for object.
+func (c *Client) newRequest(ctx context.Context, method, p string) (req *http.Request, err error) {
+	u, ok := c.urls[p]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	return http.NewRequestWithContext(ctx, method, u, nil)
+}
+
+// do will send the request and check the response status.
+func (c *Client) do(req *http.Request, status int) (r io.Reader, err error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return
+	}
+	if resp.StatusCode != status {
+		resp.Body.Close()
+		return nil, fmt.Errorf("get %s failed for status %s", req.URL, resp.Status)
+	}
+	return resp.Body, nil
+}
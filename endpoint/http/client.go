@@ -0,0 +1,131 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// Client is the struct for http url list endpoint.
+type Client struct {
+	ListPath string `yaml:"list_path"`
+
+	Path string
+
+	client *http.Client
+	// urls maps object key to its url.
+	urls map[string]string
+}
+
+// New will create a new http url list client.
+func New(ctx context.Context, et uint8, hc *http.Client) (c *Client, err error) {
+	t, err := model.GetTask(ctx)
+	if err != nil {
+		return
+	}
+
+	c = &Client{}
+
+	e := t.Src
+	if et == constants.DestinationEndpoint {
+		e = t.Dst
+	}
+
+	content, err := yaml.Marshal(e.Options)
+	if err != nil {
+		return
+	}
+	err = yaml.Unmarshal(content, c)
+	if err != nil {
+		return
+	}
+
+	// Check list path.
+	if c.ListPath == "" {
+		logrus.Error("HTTP's list path can't be empty.")
+		err = constants.ErrEndpointInvalid
+		return
+	}
+	c.ListPath, err = filepath.Abs(c.ListPath)
+	if err != nil {
+		return
+	}
+
+	c.Path = e.Path
+	c.client = hc
+
+	err = c.load()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// load will load the key to url mapping from list file.
+//
+// Every object will be read by key after qscamel restarted, so we need to
+// keep the whole mapping.
+func (c *Client) load() (err error) {
+	f, err := os.Open(c.ListPath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	c.urls = make(map[string]string)
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		key, u, err := parseLine(s.Text())
+		if err != nil {
+			logrus.Errorf("HTTP parse line %s failed for %v.", s.Text(), err)
+			return err
+		}
+		if key == "" {
+			continue
+		}
+		c.urls[key] = u
+	}
+	return s.Err()
+}
+
+// parseLine will parse a line into key and url.
+//
+// A line could be "<url>" or "<url> <key>", key will be url's path if
+// not specified. Empty line and line starts with "#" will be ignored.
+func parseLine(line string) (key, u string, err error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return "", "", nil
+	}
+	if len(fields) > 2 {
+		return "", "", constants.ErrEndpointInvalid
+	}
+
+	pu, err := url.Parse(fields[0])
+	if err != nil {
+		return
+	}
+	if pu.Scheme != "http" && pu.Scheme != "https" {
+		return "", "", constants.ErrEndpointInvalid
+	}
+
+	u = fields[0]
+	key = pu.Path
+	if len(fields) == 2 {
+		key = fields[1]
+	}
+	key = "/" + utils.Join(key)
+	return
+}
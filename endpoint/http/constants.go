@@ -0,0 +1,8 @@
+package http
+
+// List related constants.
+const (
+	// MarkerSaveInterval is the count of lines listed between saving marker,
+	// lines after the saved marker will be listed again while resuming.
+	MarkerSaveInterval = 1000
+)
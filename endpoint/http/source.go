@@ -0,0 +1,104 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/model"
+)
+
+// List implement source.List
+func (c *Client) List(ctx context.Context, j *model.DirectoryObject, fn func(o model.Object)) (err error) {
+	// Marker is the count of lines that have been listed.
+	marker := j.Marker
+	if len(marker) == 0 {
+		marker = "0"
+	}
+	done, err := strconv.ParseInt(marker, 10, 64)
+	if err != nil {
+		return
+	}
+
+	f, err := os.Open(c.ListPath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+
+	cur := int64(0)
+	for s.Scan() {
+		cur++
+		if cur <= done {
+			continue
+		}
+
+		key, _, err := parseLine(s.Text())
+		if err != nil {
+			return err
+		}
+		// Objects will be stat by migrate while handling, so that urls can
+		// be stat concurrently and bad urls will be recorded as failures.
+		if key != "" {
+			fn(&model.SingleObject{Key: key})
+		}
+
+		if cur%MarkerSaveInterval == 0 {
+			err = c.saveMarker(ctx, j, cur)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	err = s.Err()
+	if err != nil {
+		return
+	}
+	return c.saveMarker(ctx, j, cur)
+}
+
+// ListLazily implement endpoint.LazyLister
+func (c *Client) ListLazily() bool {
+	return true
+}
+
+// saveMarker will save the count of listed lines as j's marker.
+func (c *Client) saveMarker(ctx context.Context, j *model.DirectoryObject, cur int64) (err error) {
+	j.Marker = strconv.FormatInt(cur, 10)
+	err = model.CreateObject(ctx, j)
+	if err != nil {
+		logrus.Errorf("Save directory object %s failed for %v.", j.Key, err)
+		return
+	}
+	return
+}
+
+// Reach implement source.Fetch
+func (c *Client) Reach(ctx context.Context, p string) (url string, err error) {
+	u, ok := c.urls[p]
+	if !ok {
+		return "", os.ErrNotExist
+	}
+	return u, nil
+}
+
+// Reachable implement source.Reachable
+func (c *Client) Reachable() bool {
+	return true
+}
+
+// Delete implement source.Delete
+func (c *Client) Delete(ctx context.Context, p string) (err error) {
+	return constants.ErrEndpointFuncNotImplemented
+}
+
+// Deletable implement source.Deletable
+func (c *Client) Deletable() bool {
+	return false
+}
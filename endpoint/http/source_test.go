@@ -0,0 +1,155 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/db"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+const testContent = "0123456789"
+
+func newTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok.txt", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "ok.txt", time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), strings.NewReader(testContent))
+	})
+	mux.HandleFunc("/forbidden.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mux.HandleFunc("/chunked.txt", func(w http.ResponseWriter, r *http.Request) {
+		// Flush before writing body, so that content length is unknown.
+		w.(http.Flusher).Flush()
+		_, _ = fmt.Fprint(w, testContent)
+	})
+	return httptest.NewServer(mux)
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server, lines ...string) (*Client, context.Context, func()) {
+	dir, err := ioutil.TempDir("", "qscamel-http")
+	assert.NoError(t, err)
+
+	p := filepath.Join(dir, "list.txt")
+	assert.NoError(t, ioutil.WriteFile(p, []byte(strings.Join(lines, "\n")), 0644))
+
+	// Directory object and failures are stored in db.
+	d, err := db.NewDB(&db.DatabaseOptions{InMemory: true})
+	assert.NoError(t, err)
+	contexts.DB = d
+
+	c := &Client{
+		ListPath: p,
+		client:   srv.Client(),
+	}
+	assert.NoError(t, c.load())
+
+	return c, utils.NewTaskContext(context.Background(), "test"), func() {
+		d.Close()
+		contexts.DB = nil
+		os.RemoveAll(dir)
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	cases := []struct {
+		line string
+		key  string
+		url  string
+		err  bool
+	}{
+		{"", "", "", false},
+		{"# comment", "", "", false},
+		{"http://example.com/a/b.txt", "/a/b.txt", "http://example.com/a/b.txt", false},
+		{"https://example.com/a/b.txt c/d.txt", "/c/d.txt", "https://example.com/a/b.txt", false},
+		{"ftp://example.com/a", "", "", true},
+		{"http://example.com/a b c", "", "", true},
+	}
+
+	for _, v := range cases {
+		key, u, err := parseLine(v.line)
+		if v.err {
+			assert.Error(t, err, v.line)
+			continue
+		}
+		assert.NoError(t, err, v.line)
+		assert.Equal(t, v.key, key, v.line)
+		assert.Equal(t, v.url, u, v.line)
+	}
+}
+
+func TestClient_Read(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	c, ctx, closer := newTestClient(t, srv, srv.URL+"/ok.txt", srv.URL+"/chunked.txt")
+	defer closer()
+
+	r, err := c.Read(ctx, "/ok.txt", false)
+	assert.NoError(t, err)
+	content, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, testContent, string(content))
+
+	r, err = c.ReadRange(ctx, "/ok.txt", 3, 4)
+	assert.NoError(t, err)
+	content, err = ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, testContent[3:7], string(content))
+
+	o, err := c.Stat(ctx, "/ok.txt", false)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(testContent)), o.Size)
+	assert.Equal(t, int64(1136214245), o.LastModified)
+
+	_, err = c.Stat(ctx, "/chunked.txt", false)
+	assert.Error(t, err)
+
+	o, err = c.Stat(ctx, "/not-in-list.txt", false)
+	assert.NoError(t, err)
+	assert.Nil(t, o)
+}
+
+func TestClient_List(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	c, ctx, closer := newTestClient(t, srv,
+		srv.URL+"/forbidden.txt",
+		srv.URL+"/chunked.txt",
+		srv.URL+"/not-found.txt",
+		"# comment",
+		srv.URL+"/ok.txt",
+	)
+	defer closer()
+
+	j := &model.DirectoryObject{}
+	keys := make([]string, 0)
+	err := c.List(ctx, j, func(o model.Object) {
+		keys = append(keys, o.(*model.SingleObject).Key)
+	})
+	assert.NoError(t, err)
+	// Urls will not be stat while listing, bad urls are left to migrate.
+	assert.Equal(t, []string{"/forbidden.txt", "/chunked.txt", "/not-found.txt", "/ok.txt"}, keys)
+	assert.Equal(t, "5", j.Marker)
+	assert.True(t, c.ListLazily())
+
+	// Listing should be resumed from the saved marker.
+	j.Marker = "4"
+	keys = keys[:0]
+	err = c.List(ctx, j, func(o model.Object) {
+		keys = append(keys, o.(*model.SingleObject).Key)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/ok.txt"}, keys)
+}
@@ -61,4 +61,34 @@ type Source interface {
 	Reach(ctx context.Context, p string) (url string, err error)
 	// Reachable will return whether current endpoint supports reach.
 	Reachable() bool
+
+	// Delete will use endpoint to delete the path.
+	Delete(ctx context.Context, p string) (err error)
+	// Deletable will return whether current endpoint supports delete.
+	Deletable() bool
+}
+
+// BatchDeleter is the interface for endpoint which supports delete objects
+// in batch.
+type BatchDeleter interface {
+	// DeleteBatch will delete at most constants.MaxDeleteBatchSize paths
+	// in one request, paths failed to delete will be returned in failed
+	// along with err.
+	DeleteBatch(ctx context.Context, ps []string) (failed []string, err error)
+}
+
+// UploadCleaner is the interface for endpoint which supports clean up
+// incomplete multipart uploads.
+type UploadCleaner interface {
+	// CleanUploads will abort all incomplete multipart uploads under
+	// endpoint's path.
+	CleanUploads(ctx context.Context) (err error)
+}
+
+// LazyLister is the interface for source endpoint which doesn't stat objects
+// while listing, objects listed from it only have key and should be stat
+// before handling.
+type LazyLister interface {
+	// ListLazily will return whether listed objects need to be stat.
+	ListLazily() bool
 }
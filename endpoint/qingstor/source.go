@@ -2,6 +2,7 @@ package qingstor
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/pengsrc/go-shared/convert"
@@ -120,3 +121,74 @@ func (c *Client) Reach(ctx context.Context, p string) (url string, err error) {
 func (c *Client) Reachable() bool {
 	return true
 }
+
+// DeleteBatch implement source.DeleteBatch
+func (c *Client) DeleteBatch(ctx context.Context, ps []string) (failed []string, err error) {
+	objects := make([]*service.KeyType, 0, len(ps))
+	// Keys in response are full keys, map them back to paths.
+	paths := make(map[string]string, len(ps))
+	for _, p := range ps {
+		key := utils.RebuildPath(c.Path, p)
+		paths[key] = p
+		objects = append(objects, &service.KeyType{
+			Key: convert.String(key),
+		})
+	}
+
+	resp, err := c.client.DeleteMultipleObjects(&service.DeleteMultipleObjectsInput{
+		Objects: objects,
+		Quiet:   convert.Bool(true),
+	})
+	if err != nil {
+		return ps, err
+	}
+	for _, v := range resp.Errors {
+		logrus.Errorf("QingStor delete object %s failed for %s.",
+			convert.StringValue(v.Key), convert.StringValue(v.Message))
+
+		failed = append(failed, paths[convert.StringValue(v.Key)])
+	}
+	if len(failed) > 0 {
+		return failed, fmt.Errorf("%d objects delete failed", len(failed))
+	}
+
+	logrus.Debugf("QingStor deleted %d objects.", len(ps))
+	return
+}
+
+// CleanUploads implement source.CleanUploads
+func (c *Client) CleanUploads(ctx context.Context) (err error) {
+	keyMarker, uploadIDMarker := "", ""
+
+	for {
+		resp, err := c.client.ListMultipartUploads(&service.ListMultipartUploadsInput{
+			Prefix:         convert.String(utils.RebuildPath(c.Path, "")),
+			KeyMarker:      convert.String(keyMarker),
+			UploadIDMarker: convert.String(uploadIDMarker),
+			Limit:          convert.Int(MaxListObjectsLimit),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, v := range resp.Uploads {
+			key := convert.StringValue(v.Key)
+			_, err = c.client.AbortMultipartUpload(key, &service.AbortMultipartUploadInput{
+				UploadID: v.UploadID,
+			})
+			if err != nil {
+				return err
+			}
+
+			logrus.Infof("QingStor aborted multipart upload %s of object %s.",
+				convert.StringValue(v.UploadID), key)
+		}
+
+		keyMarker = convert.StringValue(resp.NextKeyMarker)
+		uploadIDMarker = convert.StringValue(resp.NextUploadIDMarker)
+		if !convert.BoolValue(resp.HasMore) || keyMarker == "" {
+			break
+		}
+	}
+	return
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
 )
 
 // Client is the client to visit aliyun oss service.
@@ -21,6 +22,8 @@ type Client struct {
 	Domain        string `yaml:"domain"`
 	UseHTTPS      bool   `yaml:"use_https"`
 	UseCdnDomains bool   `yaml:"use_cdn_domains"`
+	// PresignExpire is the expire seconds of presigned url used in fetch.
+	PresignExpire int64 `yaml:"presign_expire"`
 
 	Path string
 
@@ -80,6 +83,12 @@ func New(ctx context.Context, et uint8, hc *http.Client) (c *Client, err error)
 		return
 	}
 
+	// Set presign expire.
+	c.PresignExpire, err = utils.CheckPresignExpire("Qiniu", c.PresignExpire, DefaultPresignExpire)
+	if err != nil {
+		return
+	}
+
 	// Set prefix.
 	c.Path = e.Path
 
@@ -10,3 +10,6 @@ const ErrorCodeNotFound = 612
 // ErrorCodeInvalidMarker is the error code returned when input marker is invalid.
 // ref: https://developer.qiniu.com/kodo/api/3928/error-responses
 const ErrorCodeInvalidMarker = 640
+
+// DefaultPresignExpire is the default expire seconds for presigned url.
+const DefaultPresignExpire = 3600
@@ -8,6 +8,7 @@ import (
 	"github.com/qiniu/x/rpc.v7"
 	"github.com/sirupsen/logrus"
 
+	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/model"
 	"github.com/yunify/qscamel/utils"
 )
@@ -76,7 +77,7 @@ func (c *Client) List(ctx context.Context, j *model.DirectoryObject, fn func(o m
 func (c *Client) Reach(ctx context.Context, p string) (url string, err error) {
 	cp := utils.Join(c.Path, p)
 
-	deadline := time.Now().Add(time.Hour).Unix()
+	deadline := time.Now().Add(time.Duration(c.PresignExpire) * time.Second).Unix()
 	url = storage.MakePrivateURL(c.mac, c.Domain, cp, deadline)
 	return
 }
@@ -85,3 +86,13 @@ func (c *Client) Reach(ctx context.Context, p string) (url string, err error) {
 func (c *Client) Reachable() bool {
 	return true
 }
+
+// Delete implement source.Delete
+func (c *Client) Delete(ctx context.Context, p string) (err error) {
+	return constants.ErrEndpointFuncNotImplemented
+}
+
+// Deletable implement source.Deletable
+func (c *Client) Deletable() bool {
+	return false
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/endpoint/s3/signer/v2"
 	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
 )
 
 // Client is the client to visit service.
@@ -31,6 +32,8 @@ type Client struct {
 	EnableListObjectsV2 bool   `yaml:"enable_list_objects_v2"`
 	EnableSignatureV2   bool   `yaml:"enable_signature_v2"`
 	DisableURICleaning  bool   `yaml:"disable_uri_cleaning"`
+	// PresignExpire is the expire seconds of presigned url used in fetch.
+	PresignExpire int64 `yaml:"presign_expire"`
 
 	Path string
 
@@ -81,6 +84,12 @@ func New(ctx context.Context, et uint8, hc *http.Client) (c *Client, err error)
 		return
 	}
 
+	// Set presign expire.
+	c.PresignExpire, err = utils.CheckPresignExpire("AWS", c.PresignExpire, DefaultPresignExpire)
+	if err != nil {
+		return
+	}
+
 	// Set path.
 	c.Path = e.Path
 
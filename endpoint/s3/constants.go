@@ -9,6 +9,9 @@ const ErrorCodeNotFound = "NoSuchKey"
 // MaxListObjectsLimit is the max limit for list objects.
 const MaxListObjectsLimit = 1000
 
+// DefaultPresignExpire is the default expire seconds for presigned url.
+const DefaultPresignExpire = 3600
+
 // Multipart related constants.
 // ref: https://docsv4.qingcloud.com/user_guide/storage/object_storage/api/object/multipart/
 const (
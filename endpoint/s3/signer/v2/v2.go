@@ -29,6 +29,7 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -82,6 +83,14 @@ func Sign(req *request.Request) {
 		return
 	}
 
+	// Presigned request carries the expire time in query instead of the
+	// Authorization header.
+	if req.ExpireTime > 0 {
+		query := req.HTTPRequest.URL.Query()
+		query.Set("Expires", strconv.FormatInt(req.Time.Add(req.ExpireTime).Unix(), 10))
+		req.HTTPRequest.URL.RawQuery = query.Encode()
+	}
+
 	v2 := signer{
 		Request:     req.HTTPRequest,
 		Time:        req.Time,
@@ -197,6 +206,7 @@ func (v2 *signer) Sign() error {
 
 	if expires {
 		params["Signature"] = []string{v2.signature}
+		v2.Request.URL.RawQuery = params.Encode()
 	} else {
 		headers["Authorization"] = []string{"AWS " + accessKey + ":" + v2.signature}
 	}
@@ -2,13 +2,14 @@ package s3
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/sirupsen/logrus"
 
-	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/model"
 	"github.com/yunify/qscamel/utils"
 )
@@ -152,10 +153,87 @@ func (c *Client) List(ctx context.Context, j *model.DirectoryObject, fn func(o m
 
 // Reach implement source.Fetch
 func (c *Client) Reach(ctx context.Context, p string) (url string, err error) {
-	return "", constants.ErrEndpointFuncNotImplemented
+	cp := utils.RebuildPath(c.Path, p)
+
+	req, _ := c.client.GetObjectRequest(&s3.GetObjectInput{
+		Key:    aws.String(cp),
+		Bucket: aws.String(c.BucketName),
+	})
+	req.SetContext(ctx)
+
+	// Request will be signed by v2 or v4 signer which is set in New.
+	return req.Presign(time.Duration(c.PresignExpire) * time.Second)
 }
 
 // Readable implement source.Readable
 func (c *Client) Readable() bool {
 	return false
 }
+
+// DeleteBatch implement source.DeleteBatch
+func (c *Client) DeleteBatch(ctx context.Context, ps []string) (failed []string, err error) {
+	objects := make([]*s3.ObjectIdentifier, 0, len(ps))
+	// Keys in response are full keys, map them back to paths.
+	paths := make(map[string]string, len(ps))
+	for _, p := range ps {
+		key := utils.RebuildPath(c.Path, p)
+		paths[key] = p
+		objects = append(objects, &s3.ObjectIdentifier{
+			Key: aws.String(key),
+		})
+	}
+
+	resp, err := c.client.DeleteObjects(&s3.DeleteObjectsInput{
+		Bucket: aws.String(c.BucketName),
+		Delete: &s3.Delete{
+			Objects: objects,
+			Quiet:   aws.Bool(true),
+		},
+	})
+	if err != nil {
+		return ps, err
+	}
+	for _, v := range resp.Errors {
+		logrus.Errorf("s3 delete object %s failed for %s.",
+			aws.StringValue(v.Key), aws.StringValue(v.Message))
+
+		failed = append(failed, paths[aws.StringValue(v.Key)])
+	}
+	if len(failed) > 0 {
+		return failed, fmt.Errorf("%d objects delete failed", len(failed))
+	}
+
+	logrus.Debugf("s3 deleted %d objects.", len(ps))
+	return
+}
+
+// CleanUploads implement source.CleanUploads
+func (c *Client) CleanUploads(ctx context.Context) (err error) {
+	var uploads []*s3.MultipartUpload
+
+	err = c.client.ListMultipartUploadsPages(&s3.ListMultipartUploadsInput{
+		Bucket: aws.String(c.BucketName),
+		Prefix: aws.String(utils.RebuildPath(c.Path, "")),
+	}, func(resp *s3.ListMultipartUploadsOutput, _ bool) bool {
+		uploads = append(uploads, resp.Uploads...)
+		return true
+	})
+	if err != nil {
+		return
+	}
+
+	for _, v := range uploads {
+		_, err = c.client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(c.BucketName),
+			Key:      v.Key,
+			UploadId: v.UploadId,
+		})
+		if err != nil {
+			return
+		}
+
+		logrus.Infof("s3 aborted multipart upload %s of object %s.",
+			aws.StringValue(v.UploadId), aws.StringValue(v.Key))
+	}
+	return
+}
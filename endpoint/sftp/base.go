@@ -0,0 +1,73 @@
+package sftp
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+
+	"github.com/yunify/qscamel/model"
+)
+
+// Name implement base.Read
+func (c *Client) Name(ctx context.Context) (name string) {
+	return "sftp:" + c.Address
+}
+
+// Read implement source.Read
+func (c *Client) Read(ctx context.Context, p string, _ bool) (r io.Reader, err error) {
+	cp := path.Join(c.Path, p)
+
+	f, err := c.client.Open(cp)
+	if err != nil {
+		return
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return
+	}
+	return newFileReader(f, f, fi.Size()), nil
+}
+
+// ReadRange implement source.ReadRange
+func (c *Client) ReadRange(
+	ctx context.Context, p string, offset, size int64,
+) (r io.Reader, err error) {
+	cp := path.Join(c.Path, p)
+
+	f, err := c.client.Open(cp)
+	if err != nil {
+		return
+	}
+
+	_, err = f.Seek(offset, io.SeekStart)
+	if err != nil {
+		_ = f.Close()
+		return
+	}
+
+	return newFileReader(f, f, size), nil
+}
+
+// Stat implement source.Stat and destination.Stat
+func (c *Client) Stat(ctx context.Context, p string, _ bool) (o *model.SingleObject, err error) {
+	cp := path.Join(c.Path, p)
+
+	fi, err := c.client.Stat(cp)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return
+	}
+
+	// We will not calculate md5 while stating object.
+	o = &model.SingleObject{
+		Key:          p,
+		Size:         fi.Size(),
+		LastModified: fi.ModTime().Unix(),
+	}
+	return
+}
@@ -0,0 +1,154 @@
+package sftp
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/sftp"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"gopkg.in/yaml.v2"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/model"
+)
+
+// Client is the struct for SFTP endpoint.
+type Client struct {
+	Address  string `yaml:"address"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+
+	PrivateKey           string `yaml:"private_key"`
+	PrivateKeyPassphrase string `yaml:"private_key_passphrase"`
+	KnownHosts           string `yaml:"known_hosts"`
+
+	Path string
+
+	client *sftp.Client
+}
+
+// New will create a client.
+func New(ctx context.Context, et uint8, _ *http.Client) (c *Client, err error) {
+	t, err := model.GetTask(ctx)
+	if err != nil {
+		return
+	}
+
+	c = &Client{}
+
+	e := t.Src
+	if et == constants.DestinationEndpoint {
+		e = t.Dst
+	}
+
+	content, err := yaml.Marshal(e.Options)
+	if err != nil {
+		return
+	}
+	err = yaml.Unmarshal(content, c)
+	if err != nil {
+		return
+	}
+
+	// Check address.
+	if c.Address == "" {
+		logrus.Error("SFTP's address can't be empty.")
+		err = constants.ErrEndpointInvalid
+		return
+	}
+	if _, _, err = net.SplitHostPort(c.Address); err != nil {
+		c.Address = net.JoinHostPort(c.Address, DefaultPort)
+	}
+	// Check user.
+	if c.User == "" {
+		logrus.Error("SFTP's user can't be empty.")
+		err = constants.ErrEndpointInvalid
+		return
+	}
+	// Check auth.
+	if c.Password == "" && c.PrivateKey == "" {
+		logrus.Error("SFTP's password and private key can't be both empty.")
+		err = constants.ErrEndpointInvalid
+		return
+	}
+	// Set known hosts.
+	if c.KnownHosts == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		c.KnownHosts = filepath.Join(home, DefaultKnownHosts)
+	}
+
+	c.Path = e.Path
+
+	err = c.connect()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// connect will dial the ssh server and start a sftp session.
+func (c *Client) connect() (err error) {
+	cfg, err := c.clientConfig()
+	if err != nil {
+		return
+	}
+
+	conn, err := ssh.Dial("tcp", c.Address, cfg)
+	if err != nil {
+		logrus.Errorf("SFTP dial %s failed for %v.", c.Address, err)
+		return
+	}
+
+	c.client, err = sftp.NewClient(conn)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	return
+}
+
+// clientConfig will build the ssh client config from options.
+func (c *Client) clientConfig() (cfg *ssh.ClientConfig, err error) {
+	hostKeyCallback, err := knownhosts.New(c.KnownHosts)
+	if err != nil {
+		logrus.Errorf("SFTP load known hosts %s failed for %v.", c.KnownHosts, err)
+		return
+	}
+
+	cfg = &ssh.ClientConfig{
+		User:            c.User,
+		HostKeyCallback: hostKeyCallback,
+	}
+
+	if c.PrivateKey != "" {
+		content, err := ioutil.ReadFile(c.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		var signer ssh.Signer
+		if c.PrivateKeyPassphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(content, []byte(c.PrivateKeyPassphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey(content)
+		}
+		if err != nil {
+			logrus.Errorf("SFTP parse private key %s failed for %v.", c.PrivateKey, err)
+			return nil, err
+		}
+		cfg.Auth = append(cfg.Auth, ssh.PublicKeys(signer))
+	}
+	if c.Password != "" {
+		cfg.Auth = append(cfg.Auth, ssh.Password(c.Password))
+	}
+	return
+}
@@ -0,0 +1,284 @@
+package sftp
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/yunify/qscamel/model"
+)
+
+const (
+	testUser     = "qscamel"
+	testPassword = "password"
+)
+
+// testServer is an in-process ssh server which serves sftp subsystem on
+// the local file system.
+type testServer struct {
+	addr       string
+	knownHosts string
+	privateKey string
+
+	l net.Listener
+}
+
+func newTestServer(t *testing.T, dir string) *testServer {
+	hostKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	assert.NoError(t, err)
+
+	userKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	userSigner, err := ssh.NewSignerFromKey(userKey)
+	assert.NoError(t, err)
+
+	cfg := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == testUser && string(pass) == testPassword {
+				return nil, nil
+			}
+			return nil, assert.AnError
+		},
+		PublicKeyCallback: func(c ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if c.User() == testUser && bytes.Equal(key.Marshal(), userSigner.PublicKey().Marshal()) {
+				return nil, nil
+			}
+			return nil, assert.AnError
+		},
+	}
+	cfg.AddHostKey(hostSigner)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	s := &testServer{
+		addr:       l.Addr().String(),
+		knownHosts: filepath.Join(dir, "known_hosts"),
+		privateKey: filepath.Join(dir, "id_ecdsa"),
+		l:          l,
+	}
+
+	err = ioutil.WriteFile(s.knownHosts,
+		[]byte(knownhosts.Line([]string{s.addr}, hostSigner.PublicKey())+"\n"), 0600)
+	assert.NoError(t, err)
+
+	der, err := x509.MarshalECPrivateKey(userKey)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(s.privateKey,
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600)
+	assert.NoError(t, err)
+
+	go s.serve(cfg)
+	return s
+}
+
+func (s *testServer) serve(cfg *ssh.ServerConfig) {
+	for {
+		conn, err := s.l.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn, cfg)
+	}
+}
+
+func (s *testServer) handle(conn net.Conn, cfg *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, cfg)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for nc := range chans {
+		if nc.ChannelType() != "session" {
+			_ = nc.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		ch, reqs, err := nc.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			for req := range reqs {
+				ok := req.Type == "subsystem" && string(req.Payload[4:]) == "sftp"
+				_ = req.Reply(ok, nil)
+				if !ok {
+					continue
+				}
+
+				server, err := sftp.NewServer(ch)
+				if err != nil {
+					_ = ch.Close()
+					return
+				}
+				_ = server.Serve()
+				_ = ch.Close()
+				return
+			}
+		}()
+	}
+}
+
+func (s *testServer) Close() {
+	_ = s.l.Close()
+}
+
+func newTestClient(t *testing.T) (*Client, *testServer, func()) {
+	dir, err := ioutil.TempDir("", "qscamel-sftp")
+	assert.NoError(t, err)
+
+	s := newTestServer(t, dir)
+
+	root := filepath.Join(dir, "root")
+	assert.NoError(t, os.Mkdir(root, 0755))
+
+	c := &Client{
+		Address:    s.addr,
+		User:       testUser,
+		Password:   testPassword,
+		KnownHosts: s.knownHosts,
+		Path:       root,
+	}
+	assert.NoError(t, c.connect())
+
+	return c, s, func() {
+		_ = c.client.Close()
+		s.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestClient_Auth(t *testing.T) {
+	c, s, closer := newTestClient(t)
+	defer closer()
+
+	cases := []struct {
+		name   string
+		client *Client
+		valid  bool
+	}{
+		{"private key", &Client{PrivateKey: s.privateKey, KnownHosts: s.knownHosts}, true},
+		{"wrong password", &Client{Password: "wrong", KnownHosts: s.knownHosts}, false},
+		{"unknown host", &Client{Password: testPassword, KnownHosts: filepath.Join(c.Path, "empty")}, false},
+	}
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(c.Path, "empty"), nil, 0600))
+
+	for _, v := range cases {
+		v.client.Address = s.addr
+		v.client.User = testUser
+
+		err := v.client.connect()
+		if v.valid {
+			assert.NoError(t, err, v.name)
+			_ = v.client.client.Close()
+		} else {
+			assert.Error(t, err, v.name)
+		}
+	}
+}
+
+func TestClient_Write(t *testing.T) {
+	c, _, closer := newTestClient(t)
+	defer closer()
+
+	ctx := context.Background()
+	content := []byte("hello, qscamel")
+
+	err := c.Write(ctx, "/a/b/c.txt", int64(len(content)), bytes.NewReader(content), false, nil)
+	assert.NoError(t, err)
+	got, err := ioutil.ReadFile(filepath.Join(c.Path, "a", "b", "c.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, content, got)
+
+	err = c.Write(ctx, "/d", 0, nil, true, nil)
+	assert.NoError(t, err)
+	fi, err := os.Stat(filepath.Join(c.Path, "d"))
+	assert.NoError(t, err)
+	assert.True(t, fi.IsDir())
+
+	o, err := c.Stat(ctx, "/a/b/c.txt", false)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(content)), o.Size)
+
+	err = c.Delete(ctx, "/a/b/c.txt")
+	assert.NoError(t, err)
+	o, err = c.Stat(ctx, "/a/b/c.txt", false)
+	assert.NoError(t, err)
+	assert.Nil(t, o)
+}
+
+func TestClient_List(t *testing.T) {
+	c, _, closer := newTestClient(t)
+	defer closer()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(c.Path, "a", "b"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(c.Path, "a", "x.txt"), []byte("x"), 0644))
+	assert.NoError(t, os.Symlink("x.txt", filepath.Join(c.Path, "a", "link")))
+
+	var dirs, files []string
+	err := c.List(context.Background(), &model.DirectoryObject{Key: "/a"}, func(o model.Object) {
+		switch x := o.(type) {
+		case *model.DirectoryObject:
+			dirs = append(dirs, x.Key)
+		case *model.SingleObject:
+			files = append(files, x.Key)
+			assert.Equal(t, int64(1), x.Size)
+		}
+	})
+	assert.NoError(t, err)
+	sort.Strings(files)
+	assert.Equal(t, []string{"/a/b"}, dirs)
+	assert.Equal(t, []string{"/a/x.txt"}, files)
+}
+
+func TestClient_ReadRange(t *testing.T) {
+	c, _, closer := newTestClient(t)
+	defer closer()
+
+	ctx := context.Background()
+	content := []byte("0123456789")
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(c.Path, "r.txt"), content, 0644))
+
+	r, err := c.Read(ctx, "/r.txt", false)
+	assert.NoError(t, err)
+	got, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, content, got)
+
+	r, err = c.ReadRange(ctx, "/r.txt", 3, 4)
+	assert.NoError(t, err)
+	got, err = ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, content[3:7], got)
+	assert.NoError(t, r.(io.Closer).Close())
+
+	// Reader closed before read finished should release the handle once.
+	r, err = c.Read(ctx, "/r.txt", false)
+	assert.NoError(t, err)
+	_, err = io.ReadFull(r, make([]byte, 3))
+	assert.NoError(t, err)
+	assert.NoError(t, r.(io.Closer).Close())
+	assert.NoError(t, r.(io.Closer).Close())
+	_, err = r.Read(make([]byte, 3))
+	assert.Error(t, err)
+}
@@ -0,0 +1,9 @@
+package sftp
+
+// Connection related constants.
+const (
+	// DefaultPort is the default ssh port.
+	DefaultPort = "22"
+	// DefaultKnownHosts is the default known hosts file relative to home.
+	DefaultKnownHosts = ".ssh/known_hosts"
+)
@@ -0,0 +1,112 @@
+package sftp
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/model"
+)
+
+// Deletable implement destination.Deletable
+func (c *Client) Deletable() bool {
+	return true
+}
+
+// Fetchable implement destination.Fetchable
+func (c *Client) Fetchable() bool {
+	return false
+}
+
+// Writable implement destination.Writable
+func (c *Client) Writable() bool {
+	return true
+}
+
+// Delete implement destination.Delete
+func (c *Client) Delete(ctx context.Context, p string) (err error) {
+	cp := path.Join(c.Path, p)
+
+	err = c.client.Remove(cp)
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("SFTP delete file %s.", cp)
+	return
+}
+
+// Write implement destination.Write
+func (c *Client) Write(ctx context.Context, p string, size int64, r io.Reader, isDir bool, _ map[string]string) (err error) {
+	cp := path.Join(c.Path, p)
+
+	if isDir {
+		err = c.client.MkdirAll(cp)
+		if err != nil {
+			return
+		}
+		logrus.Debugf("SFTP created dir %s.", cp)
+		return
+	}
+
+	_, err = c.client.Stat(path.Dir(cp))
+	if os.IsNotExist(err) {
+		err = c.client.MkdirAll(path.Dir(cp))
+		if err != nil {
+			return
+		}
+		logrus.Debugf("SFTP created dir %s.", path.Dir(cp))
+	}
+
+	file, err := c.client.Create(cp)
+	if err != nil {
+		return
+	}
+
+	// wrap by limitReader to keep body consistent with size
+	_, err = io.Copy(file, io.LimitReader(r, size))
+	if err != nil {
+		_ = file.Close()
+		return
+	}
+	err = file.Close()
+	if err != nil {
+		return
+	}
+
+	logrus.Debugf("SFTP wrote file %s.", cp)
+	return
+}
+
+// Fetch implement destination.Fetch
+func (c *Client) Fetch(ctx context.Context, p, url string) (err error) {
+	return
+}
+
+// Partable implement destination.Partable
+func (c *Client) Partable() bool {
+	return false
+}
+
+// InitPart implement destination.InitPart
+func (c *Client) InitPart(ctx context.Context, p string, size int64, _ map[string]string) (uploadID string, partSize int64, partNumbers int, err error) {
+	return "", 0, 0, nil
+}
+
+// UploadPart implement destination.UploadPart
+func (c *Client) UploadPart(ctx context.Context, o *model.PartialObject, r io.Reader) (err error) {
+	return nil
+}
+
+// CompleteParts implement destination.CompleteParts
+func (c *Client) CompleteParts(ctx context.Context, path string, uploadId string, totalNumber int) (err error) {
+	return nil
+}
+
+// AbortUploads implement destination.AbortUploads
+func (c *Client) AbortUploads(ctx context.Context, path string, uploadId string) (err error) {
+	return nil
+}
@@ -0,0 +1,62 @@
+package sftp
+
+import (
+	"context"
+	"path"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// List implement source.List
+func (c *Client) List(ctx context.Context, j *model.DirectoryObject, fn func(o model.Object)) (err error) {
+	cp := path.Join(c.Path, j.Key)
+
+	list, err := c.client.ReadDir(cp)
+	if err != nil {
+		logrus.Warnf("SFTP read dir <%s> failed: [%v]", cp, err)
+		return
+	}
+
+	for _, v := range list {
+		if v.IsDir() {
+			o := &model.DirectoryObject{
+				Key: "/" + utils.Join(j.Key, v.Name()),
+			}
+
+			fn(o)
+
+			continue
+		}
+
+		// Skip irregular file, such as: symlink, device, io pipe, etc.
+		if !v.Mode().IsRegular() {
+			logrus.Infof("target <%s> skipped because its not a regular file",
+				path.Join(cp, v.Name()))
+			continue
+		}
+
+		o := &model.SingleObject{
+			Key:          "/" + utils.Join(j.Key, v.Name()),
+			Size:         v.Size(),
+			LastModified: v.ModTime().Unix(),
+		}
+
+		fn(o)
+	}
+
+	return
+}
+
+// Reach implement source.Fetch
+func (c *Client) Reach(ctx context.Context, p string) (url string, err error) {
+	return "", constants.ErrEndpointFuncNotImplemented
+}
+
+// Reachable implement source.Reachable
+func (c *Client) Reachable() bool {
+	return false
+}
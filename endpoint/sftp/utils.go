@@ -0,0 +1,50 @@
+package sftp
+
+import (
+	"io"
+	"sync"
+)
+
+// fileReader will close the remote file after read finished or closed.
+//
+// Every opened file holds a handle on the sftp server, we should release
+// it as soon as possible. Destination may stop reading right after size
+// bytes without hitting EOF, so we need to track the remaining size.
+// Migrate may close the reader while destination is still reading in
+// another goroutine, so the handle must be released only once.
+type fileReader struct {
+	r    io.Reader
+	c    io.Closer
+	size int64
+	once sync.Once
+}
+
+func newFileReader(r io.Reader, c io.Closer, size int64) *fileReader {
+	return &fileReader{r: r, c: c, size: size}
+}
+
+// Read implement io.Reader
+func (f *fileReader) Read(p []byte) (n int, err error) {
+	if f.size <= 0 {
+		_ = f.Close()
+		return 0, io.EOF
+	}
+	if int64(len(p)) > f.size {
+		p = p[:f.size]
+	}
+
+	n, err = f.r.Read(p)
+	f.size -= int64(n)
+	if err != nil || f.size <= 0 {
+		_ = f.Close()
+	}
+	return
+}
+
+// Close implement io.Closer
+func (f *fileReader) Close() (err error) {
+	f.once.Do(func() {
+		err = f.c.Close()
+	})
+	return
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
 )
 
 // Client is the client to visit service.
@@ -18,6 +19,12 @@ type Client struct {
 	Operator   string `yaml:"operator"`
 	Password   string `yaml:"password"`
 
+	// Domain and TokenSecret are used to generate token url for fetch.
+	Domain      string `yaml:"domain"`
+	TokenSecret string `yaml:"token_secret"`
+	// PresignExpire is the expire seconds of presigned url used in fetch.
+	PresignExpire int64 `yaml:"presign_expire"`
+
 	Path string
 
 	client *upyun.UpYun
@@ -65,6 +72,12 @@ func New(ctx context.Context, et uint8, hc *http.Client) (c *Client, err error)
 		return
 	}
 
+	// Set presign expire.
+	c.PresignExpire, err = utils.CheckPresignExpire("upyun", c.PresignExpire, DefaultPresignExpire)
+	if err != nil {
+		return
+	}
+
 	// Set path.
 	c.Path = e.Path
 
@@ -0,0 +1,4 @@
+package upyun
+
+// DefaultPresignExpire is the default expire seconds for presigned url.
+const DefaultPresignExpire = 3600
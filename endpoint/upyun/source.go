@@ -2,6 +2,7 @@ package upyun
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/upyun/go-sdk/upyun"
@@ -50,11 +51,31 @@ func (c *Client) List(ctx context.Context, j *model.DirectoryObject, fn func(o m
 }
 
 // Reach implement source.Fetch
+//
+// Token url requires token anti-leech enabled with token secret.
+// ref: https://help.upyun.com/knowledge-base/cdn-token-limite/
 func (c *Client) Reach(ctx context.Context, p string) (url string, err error) {
-	return "", constants.ErrEndpointFuncNotImplemented
+	if !c.Reachable() {
+		return "", constants.ErrEndpointFuncNotImplemented
+	}
+
+	cp := "/" + utils.Join(c.Path, p)
+	deadline := time.Now().Add(time.Duration(c.PresignExpire) * time.Second).Unix()
+
+	return makeTokenURL(c.Domain, cp, c.TokenSecret, deadline), nil
 }
 
 // Reachable implement source.Reachable
 func (c *Client) Reachable() bool {
+	return c.Domain != "" && c.TokenSecret != ""
+}
+
+// Delete implement source.Delete
+func (c *Client) Delete(ctx context.Context, p string) (err error) {
+	return constants.ErrEndpointFuncNotImplemented
+}
+
+// Deletable implement source.Deletable
+func (c *Client) Deletable() bool {
 	return false
 }
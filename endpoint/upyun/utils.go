@@ -0,0 +1,27 @@
+package upyun
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// makeTokenURL will generate the token url for path which expires at
+// deadline.
+//
+// _upt = MD5(secret&deadline&path)[12:20] + deadline
+func makeTokenURL(domain, p, secret string, deadline int64) string {
+	etime := strconv.FormatInt(deadline, 10)
+
+	sum := md5.Sum([]byte(secret + "&" + etime + "&" + p))
+	token := hex.EncodeToString(sum[:])[12:20] + etime
+
+	if !strings.Contains(domain, "://") {
+		domain = "http://" + domain
+	}
+	u := &url.URL{Path: p}
+	return fmt.Sprintf("%s%s?_upt=%s", strings.TrimSuffix(domain, "/"), u.EscapedPath(), token)
+}
@@ -4,16 +4,19 @@ go 1.14
 
 require (
 	cloud.google.com/go/storage v1.12.0
+	github.com/Azure/azure-storage-blob-go v0.8.0
 	github.com/Xuanwo/storage v1.0.1-0.20200428182019-4ae37d1c83db
 	github.com/aliyun/aliyun-oss-go-sdk v2.1.5+incompatible
 	github.com/aws/aws-sdk-go v1.36.29
 	github.com/cenkalti/backoff v1.1.0
 	github.com/colinmarc/hdfs/v2 v2.1.1
 	github.com/golang/snappy v0.0.0-20180518054509-2e65f85255db // indirect
+	github.com/jlaffaye/ftp v0.1.0
 	github.com/onsi/ginkgo v1.8.0 // indirect
 	github.com/onsi/gomega v1.5.0 // indirect
 	github.com/panjf2000/ants/v2 v2.8.1
 	github.com/pengsrc/go-shared v0.2.1-0.20190131101655-1999055a4a14
+	github.com/pkg/sftp v1.12.0
 	github.com/qingstor/qingstor-sdk-go/v4 v4.4.1
 	github.com/qiniu/api.v7 v0.0.0-20190307065957-039fdba59f73
 	github.com/qiniu/x v7.0.8+incompatible
@@ -25,7 +28,10 @@ require (
 	github.com/upyun/go-sdk v2.1.0+incompatible
 	github.com/vmihailenco/msgpack v3.3.3+incompatible
 	go.uber.org/ratelimit v0.2.0
+	golang.org/x/crypto v0.0.0-20200820211705-5c72a883971a
+	golang.org/x/oauth2 v0.0.0-20200902213428-5d25da1a8d43
 	golang.org/x/text v0.3.3
+	golang.org/x/time v0.0.0-20191024005414-555d28b269f0
 	google.golang.org/api v0.32.0
 	gopkg.in/natefinch/lumberjack.v2 v2.0.0-20170531160350-a96e63847dc3
 	gopkg.in/yaml.v2 v2.4.0
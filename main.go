@@ -17,6 +17,8 @@
 package main
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 
 	"github.com/yunify/qscamel/commands"
@@ -45,6 +47,20 @@ func init() {
 	application.AddCommand(commands.CleanCmd)
 	// Add status command.
 	application.AddCommand(commands.StatusCmd)
+	// Add report command.
+	application.AddCommand(commands.ReportCmd)
+	// Add pause command.
+	application.AddCommand(commands.PauseCmd)
+	// Add resume command.
+	application.AddCommand(commands.ResumeCmd)
+	// Add bandwidth command.
+	application.AddCommand(commands.BandwidthCmd)
+	// Add retry command.
+	application.AddCommand(commands.RetryCmd)
+	// Add failures command.
+	application.AddCommand(commands.FailuresCmd)
+	// Add plan command.
+	application.AddCommand(commands.PlanCmd)
 
 	// Add config flag which can be used in all sub commands.
 	application.PersistentFlags().StringVarP(&configPath, "config", "c", constants.ConfigPath, "config path")
@@ -53,4 +69,5 @@ func init() {
 
 func main() {
 	utils.CheckError(application.Execute)
+	os.Exit(commands.ExitCode)
 }
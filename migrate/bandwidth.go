@@ -0,0 +1,105 @@
+package migrate
+
+import (
+	"context"
+	"io"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/endpoint"
+)
+
+var (
+	// Bandwidth limiters for task, src and dst, they are shared by all
+	// single and partial objects.
+	tbl *rate.Limiter
+	sbl *rate.Limiter
+	dbl *rate.Limiter
+)
+
+// newBandwidthLimiter will create a token bucket which allows n bytes per
+// second, n <= 0 means no limit.
+func newBandwidthLimiter(n int64) *rate.Limiter {
+	return rate.NewLimiter(bandwidthLimit(n), constants.BandwidthBurstSize)
+}
+
+func bandwidthLimit(n int64) rate.Limit {
+	if n <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(n)
+}
+
+// SetBandwidthLimit will change the bandwidth limit of target while task
+// running, n <= 0 means no limit.
+func SetBandwidthLimit(target string, n int64) (err error) {
+	var l *rate.Limiter
+	switch target {
+	case constants.BandwidthTargetTask:
+		l = tbl
+	case constants.BandwidthTargetSource:
+		l = sbl
+	case constants.BandwidthTargetDestination:
+		l = dbl
+	default:
+		logrus.Errorf("Bandwidth limit target %s is not supported.", target)
+		return constants.ErrControlInvalid
+	}
+	if l == nil {
+		logrus.Errorf("Task is not running.")
+		return constants.ErrTaskNotStarted
+	}
+
+	l.SetLimit(bandwidthLimit(n))
+	logrus.Infof("Bandwidth limit of %s has been set to %d.", target, n)
+	return
+}
+
+// endpointLimiter will return the bandwidth limiter of endpoint e.
+func endpointLimiter(e endpoint.Base) *rate.Limiter {
+	if e == endpoint.Base(src) {
+		return sbl
+	}
+	return dbl
+}
+
+// limitedReader will limit the bandwidth of reading.
+type limitedReader struct {
+	ctx context.Context
+	r   io.Reader
+	ls  []*rate.Limiter
+}
+
+// throttle will wrap r with the bandwidth limiters, the returned reader
+// should be closed to release r after used.
+func throttle(ctx context.Context, r io.Reader, ls ...*rate.Limiter) io.ReadCloser {
+	return &limitedReader{ctx: ctx, r: r, ls: ls}
+}
+
+// Read implement io.Reader
+func (r *limitedReader) Read(p []byte) (n int, err error) {
+	if len(p) > constants.BandwidthBurstSize {
+		p = p[:constants.BandwidthBurstSize]
+	}
+
+	n, err = r.r.Read(p)
+	if n <= 0 {
+		return
+	}
+	for _, l := range r.ls {
+		if e := l.WaitN(r.ctx, n); e != nil {
+			return n, e
+		}
+	}
+	return
+}
+
+// Close implement io.Closer
+func (r *limitedReader) Close() error {
+	if c, ok := r.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
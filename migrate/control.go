@@ -0,0 +1,71 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/control"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// HandleControl will handle control requests for the running task.
+func HandleControl(r *control.Request) (v interface{}, err error) {
+	if t == nil || gt == nil || t.Name != r.Task {
+		logrus.Errorf("Task %s is not running.", r.Task)
+		return nil, constants.ErrTaskNotStarted
+	}
+
+	switch r.Command {
+	case constants.ControlCommandBandwidth:
+		return nil, SetBandwidthLimit(r.Target, r.Value)
+	case constants.ControlCommandPause:
+		Pause()
+		return
+	case constants.ControlCommandResume:
+		Resume()
+		return
+	case constants.ControlCommandStatus:
+		return Status(utils.NewTaskContext(context.Background(), t.Name))
+	default:
+		logrus.Errorf("Control command %s is not supported.", r.Command)
+		return nil, constants.ErrControlInvalid
+	}
+}
+
+// Status will return the status of the running task.
+func Status(ctx context.Context) (*model.TaskStatus, error) {
+	// Take a snapshot so that workers will not be blocked while counting
+	// pending objects.
+	tl.Lock()
+	x := *t
+	x.FailedObjects = make(map[string]int, len(t.FailedObjects))
+	for k, v := range t.FailedObjects {
+		x.FailedObjects[k] = v
+	}
+	tl.Unlock()
+
+	return model.NewTaskStatus(ctx, &x)
+}
+
+// Pause will stop handling new objects and wait for running objects,
+// including their partial objects, to be finished. Objects not handled
+// are still kept in db.
+func Pause() {
+	logrus.Infof("Task %s pausing, wait for running objects.", t.Name)
+	gt.pause()
+
+	err := saveTask(nil)
+	if err != nil {
+		logrus.Errorf("Task %s save failed for %v.", t.Name, err)
+	}
+	logrus.Infof("Task %s paused.", t.Name)
+}
+
+// Resume will continue handling objects.
+func Resume() {
+	gt.resume()
+	logrus.Infof("Task %s resumed.", t.Name)
+}
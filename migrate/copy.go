@@ -4,7 +4,6 @@ import (
 	"context"
 	"sync"
 
-	"github.com/cenkalti/backoff"
 	"github.com/sirupsen/logrus"
 
 	"github.com/yunify/qscamel/constants"
@@ -63,9 +62,7 @@ func copyTask(ctx context.Context) (err error) {
 	}
 	logrus.Debugf("Start copy task.")
 
-	bo := &backoff.ZeroBackOff{}
-
-	return backoff.Retry(func() error {
+	return retryTask(ctx, func() error {
 		err := Copy(ctx)
 		if err != nil {
 			return err
@@ -77,5 +74,5 @@ func copyTask(ctx context.Context) (err error) {
 		}
 
 		return nil
-	}, bo)
+	})
 }
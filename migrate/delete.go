@@ -9,19 +9,21 @@ import (
 
 	"github.com/yunify/qscamel/constants"
 	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/endpoint"
 	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
 )
 
-// CanDelete will return whether qscamel can delete between the src and dst.
+// CanDelete will return whether qscamel can delete objects in src.
 func CanDelete() bool {
-	// If dst isn't writable, can't copy.
-	if !dst.Writable() {
+	// If src isn't deletable, can't delete.
+	if !src.Deletable() {
 		return false
 	}
 	return true
 }
 
-// Delete will do delete job between src and dst.
+// Delete will delete all objects listed from src.
 func Delete(ctx context.Context) (err error) {
 	oc = make(chan model.Object, contexts.Config.Concurrency*2)
 	jc = make(chan *model.DirectoryObject)
@@ -42,7 +44,7 @@ func Delete(ctx context.Context) (err error) {
 
 	for i := 0; i < contexts.Config.Concurrency; i++ {
 		owg.Add(1)
-		go migrateWorker(ctx)
+		go deleteWorker(ctx)
 	}
 
 	err = List(ctx)
@@ -54,18 +56,149 @@ func Delete(ctx context.Context) (err error) {
 	return
 }
 
+// deleteWorker will collect objects and delete them in batch.
+func deleteWorker(ctx context.Context) {
+	defer owg.Done()
+	defer utils.Recover()
+
+	batch := make([]*model.SingleObject, 0, constants.MaxDeleteBatchSize)
+	for o := range oc {
+		switch x := o.(type) {
+		case *model.SingleObject:
+			batch = append(batch, x)
+		default:
+			// Delete task will not create partial object, just ignore it.
+			err := model.DeleteObject(ctx, o)
+			if err != nil {
+				utils.CheckClosedDB(err)
+			}
+			continue
+		}
+
+		if len(batch) >= constants.MaxDeleteBatchSize {
+			deleteBatch(ctx, batch)
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		deleteBatch(ctx, batch)
+	}
+}
+
+// deleteBatch will delete objects from src, batch delete will be used if
+// src supports it.
+func deleteBatch(ctx context.Context, batch []*model.SingleObject) {
+	// Wait for schedule allows, objects will be kept in db if task
+	// interrupted.
+	if !gt.acquire() {
+		return
+	}
+	defer gt.release()
+
+	bo := backoff.NewExponentialBackOff()
+	bo.Multiplier = 2.0
+	backOff := backoff.WithContext(backoff.WithMaxTries(bo, 10), ctx)
+
+	// Objects in pending have not been deleted yet, only them will be
+	// retried.
+	pending := batch
+
+	var fn func() error
+	if e, ok := src.(endpoint.BatchDeleter); ok {
+		fn = func() error {
+			rl.Take()
+
+			ps := make([]string, 0, len(pending))
+			for _, v := range pending {
+				ps = append(ps, v.Key)
+			}
+
+			logrus.Infof("Start deleting %d objects.", len(ps))
+			failed, err := e.DeleteBatch(ctx, ps)
+			if err != nil {
+				logrus.Infof("Batch delete %d objects failed for %v, retried.", len(failed), err)
+				// The whole batch failed, all objects should be retried.
+				if len(failed) == 0 {
+					return err
+				}
+
+				m := make(map[string]bool, len(failed))
+				for _, v := range failed {
+					m[v] = true
+				}
+				rest := make([]*model.SingleObject, 0, len(failed))
+				for _, v := range pending {
+					if m[v.Key] {
+						rest = append(rest, v)
+					}
+				}
+				pending = rest
+				return err
+			}
+			pending = nil
+			return nil
+		}
+	} else {
+		// Objects which have been deleted will be skipped while retrying.
+		fn = func() error {
+			for len(pending) > 0 {
+				rl.Take()
+
+				key := pending[0].Key
+				logrus.Infof("Start deleting single object %s.", key)
+				err := src.Delete(ctx, key)
+				if err != nil {
+					logrus.Infof("Src delete %s failed for %v, retried.", key, err)
+					return err
+				}
+				pending = pending[1:]
+			}
+			return nil
+		}
+	}
+
+	retries := -1
+	err := backoff.Retry(func() error {
+		retries++
+		return fn()
+	}, backOff)
+	if err != nil {
+		logrus.Errorf("%s objects failed for %v.", t.Type, err)
+	}
+
+	failed := make(map[string]bool, len(pending))
+	for _, v := range pending {
+		failed[v.Key] = true
+	}
+
+	for _, v := range batch {
+		// Objects not deleted for interruption will be deleted while resuming.
+		if failed[v.Key] && ctx.Err() != nil {
+			continue
+		}
+		if failed[v.Key] {
+			recordFailure(ctx, v, withPhase(constants.FailurePhaseDelete, err), retries)
+		} else {
+			clearFailure(ctx, v.Key)
+			recordSuccess(v)
+		}
+
+		e := model.DeleteObject(ctx, v)
+		if e != nil {
+			utils.CheckClosedDB(e)
+		}
+	}
+}
+
 // deleteTask will execute a delete task.
 func deleteTask(ctx context.Context) (err error) {
-	if !CanCopy() {
-		logrus.Infof("Source type %s and destination type %s not support delete.",
-			t.Src.Type, t.Dst.Type)
+	if !CanDelete() {
+		logrus.Infof("Source type %s not support delete.", t.Src.Type)
 		return
 	}
 	logrus.Debugf("Start delete task.")
 
-	bo := &backoff.ZeroBackOff{}
-
-	return backoff.Retry(func() error {
+	err = retryTask(ctx, func() error {
 		err := Delete(ctx)
 		if err != nil {
 			return err
@@ -76,5 +209,18 @@ func deleteTask(ctx context.Context) (err error) {
 		}
 
 		return nil
-	}, bo)
+	})
+	if err != nil {
+		return
+	}
+
+	// Clean up incomplete multipart uploads under the prefix.
+	if e, ok := src.(endpoint.UploadCleaner); ok {
+		err = e.CleanUploads(ctx)
+		if err != nil {
+			logrus.Errorf("Src clean uploads failed for %v.", err)
+			return
+		}
+	}
+	return
 }
@@ -0,0 +1,92 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cenkalti/backoff"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/ratelimit"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/db"
+	"github.com/yunify/qscamel/endpoint"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// batchDeleter will fail the keys in fails once, and fail the keys in
+// permanent forever which stops retrying since the second call.
+type batchDeleter struct {
+	endpoint.Source
+
+	fails     map[string]bool
+	permanent map[string]bool
+	calls     [][]string
+}
+
+func (d *batchDeleter) DeleteBatch(ctx context.Context, ps []string) (failed []string, err error) {
+	d.calls = append(d.calls, ps)
+
+	permanent := false
+	for _, v := range ps {
+		if d.fails[v] || d.permanent[v] {
+			failed = append(failed, v)
+			permanent = permanent || d.permanent[v]
+			delete(d.fails, v)
+		}
+	}
+	if len(failed) == 0 {
+		return nil, nil
+	}
+	if permanent && len(d.calls) > 1 {
+		return failed, backoff.Permanent(errors.New("delete failed"))
+	}
+	return failed, errors.New("delete failed")
+}
+
+func setupDeleteBatch(d *batchDeleter) (context.Context, func()) {
+	database, _ := db.NewDB(&db.DatabaseOptions{InMemory: true})
+	contexts.DB = database
+
+	t = &model.Task{Type: constants.TaskTypeDelete, FailedObjects: make(map[string]int)}
+	src = d
+	rl = ratelimit.NewUnlimited()
+	gt = newGate(1)
+
+	return utils.NewTaskContext(context.Background(), "test"), func() {
+		database.Close()
+		contexts.DB = nil
+	}
+}
+
+func TestDeleteBatch(tt *testing.T) {
+	d := &batchDeleter{
+		fails:     map[string]bool{"b": true},
+		permanent: map[string]bool{"c": true},
+	}
+	ctx, closer := setupDeleteBatch(d)
+	defer closer()
+
+	deleteBatch(ctx, []*model.SingleObject{
+		{Key: "a", Size: 1},
+		{Key: "b", Size: 2},
+		{Key: "c", Size: 4},
+	})
+
+	// Only failed keys should be retried.
+	assert.Equal(tt, [][]string{{"a", "b", "c"}, {"b", "c"}}, d.calls)
+	assert.Equal(tt, int64(2), t.SuccessCount)
+	assert.Equal(tt, int64(3), t.SuccessSize)
+	assert.Equal(tt, map[string]int{"c": 1}, t.FailedObjects)
+
+	f, err := model.NextFailure(ctx, "")
+	assert.NoError(tt, err)
+	assert.Equal(tt, "c", f.Key)
+	assert.Equal(tt, constants.FailurePhaseDelete, f.Phase)
+	f, err = model.NextFailure(ctx, f.Key)
+	assert.NoError(tt, err)
+	assert.Nil(tt, f)
+}
@@ -0,0 +1,84 @@
+package migrate
+
+import (
+	"context"
+	"time"
+
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// phaseError is an error with the phase where it happened.
+type phaseError struct {
+	phase string
+	err   error
+}
+
+// Error implement error.Error
+func (e *phaseError) Error() string {
+	return e.err.Error()
+}
+
+// withPhase will mark err with phase, the phase of an already marked err
+// will be kept.
+func withPhase(phase string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*phaseError); ok {
+		return err
+	}
+	return &phaseError{phase: phase, err: err}
+}
+
+// errorPhase will return the phase of err, empty means unknown.
+func errorPhase(err error) string {
+	if e, ok := err.(*phaseError); ok {
+		return e.phase
+	}
+	return ""
+}
+
+// recordFailure will mark object o as failed and save the failure in db.
+func recordFailure(ctx context.Context, o *model.SingleObject, err error, retries int) {
+	tl.Lock()
+	t.FailedObjects[o.Key] = retries
+	tl.Unlock()
+
+	e := model.CreateFailure(ctx, &model.Failure{
+		Key:       o.Key,
+		Phase:     errorPhase(err),
+		Error:     err.Error(),
+		Retries:   retries,
+		Timestamp: time.Now().Unix(),
+		Object:    o,
+	})
+	if e != nil {
+		utils.CheckClosedDB(e)
+	}
+}
+
+// clearFailure will remove the failure of object p.
+func clearFailure(ctx context.Context, p string) {
+	tl.Lock()
+	_, ok := t.FailedObjects[p]
+	delete(t.FailedObjects, p)
+	tl.Unlock()
+	if !ok {
+		return
+	}
+
+	e := model.DeleteFailure(ctx, p)
+	if e != nil {
+		utils.CheckClosedDB(e)
+	}
+}
+
+// recordSuccess will count object o as succeeded.
+func recordSuccess(o *model.SingleObject) {
+	tl.Lock()
+	defer tl.Unlock()
+
+	t.SuccessCount++
+	t.SuccessSize += o.Size
+}
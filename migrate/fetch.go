@@ -4,7 +4,6 @@ import (
 	"context"
 	"sync"
 
-	"github.com/cenkalti/backoff"
 	"github.com/sirupsen/logrus"
 
 	"github.com/yunify/qscamel/constants"
@@ -67,9 +66,7 @@ func fetchTask(ctx context.Context) (err error) {
 	}
 	logrus.Debugf("Start fetch task.")
 
-	bo := &backoff.ZeroBackOff{}
-
-	return backoff.Retry(func() error {
+	return retryTask(ctx, func() error {
 		err := Fetch(ctx)
 		if err != nil {
 			return err
@@ -80,5 +77,5 @@ func fetchTask(ctx context.Context) (err error) {
 		}
 
 		return nil
-	}, bo)
+	})
 }
@@ -0,0 +1,127 @@
+package migrate
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/yunify/qscamel/model"
+)
+
+// filter decides whether an object should be handled by current task.
+type filter struct {
+	include      []string
+	exclude      []string
+	includeRegex []*regexp.Regexp
+	excludeRegex []*regexp.Regexp
+
+	minSize int64
+	maxSize int64
+
+	modifiedAfter  int64
+	modifiedBefore int64
+}
+
+// newFilter will create a filter from task, task should have been checked.
+func newFilter(t *model.Task) (f *filter, err error) {
+	f = &filter{
+		include:        t.Include,
+		exclude:        t.Exclude,
+		minSize:        t.MinSize,
+		maxSize:        t.MaxSize,
+		modifiedAfter:  t.ModifiedAfterTimestamp,
+		modifiedBefore: t.ModifiedBeforeTimestamp,
+	}
+
+	for _, v := range t.IncludeRegex {
+		r, err := regexp.Compile(v)
+		if err != nil {
+			return nil, err
+		}
+		f.includeRegex = append(f.includeRegex, r)
+	}
+	for _, v := range t.ExcludeRegex {
+		r, err := regexp.Compile(v)
+		if err != nil {
+			return nil, err
+		}
+		f.excludeRegex = append(f.excludeRegex, r)
+	}
+	return
+}
+
+// needModified will return whether filter needs object's last modified.
+func (f *filter) needModified() bool {
+	return f.modifiedAfter != 0 || f.modifiedBefore != 0
+}
+
+// matchDirectory will return whether a directory should be listed.
+func (f *filter) matchDirectory(key string) bool {
+	key = strings.Trim(key, "/")
+	if key == "" {
+		return true
+	}
+	return !matchGlob(f.exclude, key) && !matchRegex(f.excludeRegex, key)
+}
+
+// matchKey will return whether an object key should be handled.
+func (f *filter) matchKey(key string) bool {
+	key = strings.TrimPrefix(key, "/")
+
+	if matchGlob(f.exclude, key) || matchRegex(f.excludeRegex, key) {
+		return false
+	}
+	// Object should match one of include patterns if any is set.
+	if len(f.include) == 0 && len(f.includeRegex) == 0 {
+		return true
+	}
+	return matchGlob(f.include, key) || matchRegex(f.includeRegex, key)
+}
+
+// matchObject will return whether an object should be handled.
+func (f *filter) matchObject(o *model.SingleObject) bool {
+	if !f.matchKey(o.Key) {
+		return false
+	}
+	// Size and modified filters will not be applied to directory.
+	if o.IsDir {
+		return true
+	}
+	if o.Size < f.minSize {
+		return false
+	}
+	if f.maxSize > 0 && o.Size > f.maxSize {
+		return false
+	}
+	if f.modifiedAfter != 0 && o.LastModified < f.modifiedAfter {
+		return false
+	}
+	if f.modifiedBefore != 0 && o.LastModified >= f.modifiedBefore {
+		return false
+	}
+	return true
+}
+
+// matchGlob will match key with glob patterns, pattern without "/" will be
+// matched with the last element of key.
+func matchGlob(patterns []string, key string) bool {
+	for _, v := range patterns {
+		p := key
+		if !strings.Contains(v, "/") {
+			p = path.Base(key)
+		}
+		if ok, _ := path.Match(strings.TrimPrefix(v, "/"), p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func matchRegex(patterns []*regexp.Regexp, key string) bool {
+	for _, v := range patterns {
+		if v.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
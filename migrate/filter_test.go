@@ -0,0 +1,48 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yunify/qscamel/model"
+)
+
+func TestFilter(t *testing.T) {
+	f, err := newFilter(&model.Task{
+		Include:                 []string{"*.jpg", "docs/*"},
+		Exclude:                 []string{"tmp", "*.bak.jpg"},
+		ExcludeRegex:            []string{`^private/`},
+		MinSize:                 1,
+		MaxSize:                 100,
+		ModifiedAfterTimestamp:  1000,
+		ModifiedBeforeTimestamp: 2000,
+	})
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		object   *model.SingleObject
+		expected bool
+	}{
+		{"include basename", &model.SingleObject{Key: "/a/b.jpg", Size: 10, LastModified: 1500}, true},
+		{"include path", &model.SingleObject{Key: "docs/x.txt", Size: 10, LastModified: 1500}, true},
+		{"not included", &model.SingleObject{Key: "a/b.txt", Size: 10, LastModified: 1500}, false},
+		{"exclude glob", &model.SingleObject{Key: "a/c.bak.jpg", Size: 10, LastModified: 1500}, false},
+		{"exclude regex", &model.SingleObject{Key: "/private/d.jpg", Size: 10, LastModified: 1500}, false},
+		{"too small", &model.SingleObject{Key: "e.jpg", Size: 0, LastModified: 1500}, false},
+		{"too large", &model.SingleObject{Key: "e.jpg", Size: 101, LastModified: 1500}, false},
+		{"too old", &model.SingleObject{Key: "e.jpg", Size: 10, LastModified: 999}, false},
+		{"too new", &model.SingleObject{Key: "e.jpg", Size: 10, LastModified: 2000}, false},
+		{"directory", &model.SingleObject{Key: "docs/", IsDir: true}, true},
+	}
+
+	for _, v := range cases {
+		assert.Equal(t, v.expected, f.matchObject(v.object), v.name)
+	}
+
+	assert.True(t, f.matchDirectory(""))
+	assert.True(t, f.matchDirectory("/a"))
+	assert.False(t, f.matchDirectory("/a/tmp"))
+	assert.False(t, f.matchDirectory("private/x"))
+}
@@ -0,0 +1,105 @@
+package migrate
+
+import (
+	"sync"
+)
+
+// gate limits how many objects can be handled at the same time, limit 0
+// means paused by schedule.
+type gate struct {
+	cond *sync.Cond
+
+	limit   int
+	running int
+	// paused is set by control command, and it takes precedence over limit.
+	paused bool
+	// stopped is set while task interrupted, no more slots will be handed
+	// out.
+	stopped bool
+}
+
+func newGate(limit int) *gate {
+	return &gate{
+		cond:  sync.NewCond(&sync.Mutex{}),
+		limit: limit,
+	}
+}
+
+// acquire will block until a slot is available, false will be returned if
+// gate has been stopped.
+func (g *gate) acquire() bool {
+	g.cond.L.Lock()
+	defer g.cond.L.Unlock()
+
+	for !g.stopped && (g.paused || g.running >= g.limit) {
+		g.cond.Wait()
+	}
+	if g.stopped {
+		return false
+	}
+	g.running++
+	return true
+}
+
+// release will give back a slot.
+func (g *gate) release() {
+	g.cond.L.Lock()
+	defer g.cond.L.Unlock()
+
+	g.running--
+	g.cond.Broadcast()
+}
+
+// wait will block until gate is not paused, false will be returned if gate
+// has been stopped.
+func (g *gate) wait() bool {
+	g.cond.L.Lock()
+	defer g.cond.L.Unlock()
+
+	for !g.stopped && (g.paused || g.limit <= 0) {
+		g.cond.Wait()
+	}
+	return !g.stopped
+}
+
+// setLimit will change the limit, objects which are running will not be
+// interrupted.
+func (g *gate) setLimit(n int) {
+	g.cond.L.Lock()
+	defer g.cond.L.Unlock()
+
+	g.limit = n
+	g.cond.Broadcast()
+}
+
+// pause will stop handing out slots and block until all running objects
+// finished.
+func (g *gate) pause() {
+	g.cond.L.Lock()
+	defer g.cond.L.Unlock()
+
+	g.paused = true
+	g.cond.Broadcast()
+	for g.running > 0 {
+		g.cond.Wait()
+	}
+}
+
+// resume will continue handing out slots.
+func (g *gate) resume() {
+	g.cond.L.Lock()
+	defer g.cond.L.Unlock()
+
+	g.paused = false
+	g.cond.Broadcast()
+}
+
+// stop will wake up all waiters and refuse to hand out slots any more,
+// objects which are running will not be interrupted.
+func (g *gate) stop() {
+	g.cond.L.Lock()
+	defer g.cond.L.Unlock()
+
+	g.stopped = true
+	g.cond.Broadcast()
+}
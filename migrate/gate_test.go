@@ -0,0 +1,32 @@
+package migrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGate(t *testing.T) {
+	g := newGate(1)
+	assert.True(t, g.acquire())
+
+	acquired := make(chan bool)
+	go func() {
+		acquired <- g.acquire()
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("acquire should block while gate is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	g.stop()
+	assert.False(t, <-acquired)
+	assert.False(t, g.acquire())
+	assert.False(t, g.wait())
+
+	g.release()
+	assert.Equal(t, 0, g.running)
+}
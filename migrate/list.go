@@ -23,7 +23,7 @@ func List(ctx context.Context) (err error) {
 		}
 
 		t.Status = constants.TaskStatusRunning
-		err = t.Save(ctx)
+		err = saveTask(ctx)
 		if err != nil {
 			logrus.Panic(err)
 		}
@@ -43,6 +43,9 @@ func List(ctx context.Context) (err error) {
 		if so == nil {
 			break
 		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 
 		oc <- so
 		p = so.Key
@@ -59,12 +62,26 @@ func List(ctx context.Context) (err error) {
 		if po == nil {
 			break
 		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 
 		oc <- po
 		p = po.Key
 		pn = po.PartNumber
 	}
 
+	// Root directory object will be kept if task interrupted while listing
+	// it, and it can't be found by NextDirectoryObject.
+	ro, err := model.GetDirectoryObject(ctx, "")
+	if err != nil {
+		logrus.Panic(err)
+	}
+	if ro != nil {
+		jwg.Add(1)
+		jc <- ro
+	}
+
 	// Traverse already running but not finished directory object.
 	p = ""
 	for {
@@ -75,6 +92,9 @@ func List(ctx context.Context) (err error) {
 		if do == nil {
 			break
 		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 
 		jwg.Add(1)
 		jc <- do
@@ -89,9 +109,20 @@ func listWorker(ctx context.Context) {
 	defer utils.Recover()
 
 	for j := range jc {
+		// Listing should also be paused by schedule, job will be kept in db
+		// if task interrupted.
+		if !gt.wait() {
+			jwg.Done()
+			continue
+		}
+
 		logrus.Infof("Start listing job %s.", j.Key)
 
 		err := listObject(ctx, j)
+		if err != nil && ctx.Err() != nil {
+			logrus.Infof("Job %s interrupted.", j.Key)
+			continue
+		}
 		if err != nil {
 			logrus.Errorf("List object %s failed for %v.", j.Key, err)
 			continue
@@ -0,0 +1,129 @@
+package migrate
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/model"
+)
+
+// keyMapper transforms src key to dst key.
+type keyMapper struct {
+	rules []func(p string) string
+}
+
+// newKeyMapper will create a key mapper from task, task should have been
+// checked.
+func newKeyMapper(t *model.Task) (m *keyMapper, err error) {
+	m = &keyMapper{}
+
+	for _, v := range t.KeyMapping {
+		v := v
+
+		var fn func(p string) string
+		switch v.Type {
+		case constants.KeyMappingStripPrefix:
+			prefix := strings.TrimPrefix(v.Value, "/")
+			fn = func(p string) string {
+				return strings.TrimPrefix(p, prefix)
+			}
+		case constants.KeyMappingAddPrefix:
+			prefix := strings.TrimPrefix(v.Value, "/")
+			fn = func(p string) string {
+				return prefix + p
+			}
+		case constants.KeyMappingDatePrefix:
+			// Use task's created time so that the prefix is stable while
+			// resuming.
+			prefix := strings.TrimPrefix(time.Unix(t.CreatedAt, 0).Format(v.Value), "/")
+			fn = func(p string) string {
+				return prefix + p
+			}
+		case constants.KeyMappingLowercase:
+			fn = strings.ToLower
+		case constants.KeyMappingRegex:
+			r, err := regexp.Compile(v.Pattern)
+			if err != nil {
+				return nil, err
+			}
+			fn = func(p string) string {
+				return r.ReplaceAllString(p, v.Replace)
+			}
+		default:
+			return nil, constants.ErrTaskInvalid
+		}
+		m.rules = append(m.rules, fn)
+	}
+	return
+}
+
+// enabled will return whether there are any rules.
+func (m *keyMapper) enabled() bool {
+	return len(m.rules) > 0
+}
+
+// mapKey will transform src key to dst key, leading "/" will be kept.
+func (m *keyMapper) mapKey(p string) string {
+	if !m.enabled() {
+		return p
+	}
+
+	prefix := ""
+	if strings.HasPrefix(p, "/") {
+		prefix = "/"
+	}
+
+	p = strings.TrimPrefix(p, "/")
+	for _, fn := range m.rules {
+		p = fn(p)
+	}
+	return prefix + strings.TrimPrefix(p, "/")
+}
+
+// dstObject will return a copy of object with dst key.
+func dstObject(o *model.SingleObject) *model.SingleObject {
+	if !km.enabled() {
+		return o
+	}
+
+	x := *o
+	x.Key = km.mapKey(o.Key)
+	return &x
+}
+
+// checkKeyMapping will check whether the dst key of src key p collides
+// with another src key.
+func checkKeyMapping(ctx context.Context, p string) (ok bool, err error) {
+	if !km.enabled() {
+		return true, nil
+	}
+
+	dk := km.mapKey(p)
+	if dk == "" || (strings.HasSuffix(dk, "/") && !strings.HasSuffix(p, "/")) {
+		logrus.Errorf("Object %s is mapped to invalid key %s.", p, dk)
+		return false, nil
+	}
+
+	s, found, err := model.GetKeyMapping(ctx, dk)
+	if err != nil {
+		return
+	}
+	if found && s != p {
+		logrus.Errorf("Object %s and %s are both mapped to %s.", s, p, dk)
+		return false, nil
+	}
+	if found {
+		return true, nil
+	}
+
+	err = model.CreateKeyMapping(ctx, dk, p)
+	if err != nil {
+		return
+	}
+	return true, nil
+}
@@ -0,0 +1,45 @@
+package migrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/model"
+)
+
+func TestKeyMapper(t *testing.T) {
+	createdAt := time.Date(2021, 3, 1, 12, 0, 0, 0, time.Local)
+
+	m, err := newKeyMapper(&model.Task{
+		CreatedAt: createdAt.Unix(),
+		KeyMapping: []*model.KeyMappingRule{
+			{Type: constants.KeyMappingStripPrefix, Value: "/old/"},
+			{Type: constants.KeyMappingLowercase},
+			{Type: constants.KeyMappingRegex, Pattern: `\.jpeg$`, Replace: ".jpg"},
+			{Type: constants.KeyMappingDatePrefix, Value: "2006/01/02/"},
+			{Type: constants.KeyMappingAddPrefix, Value: "backup/"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, m.enabled())
+
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"old/A/B.JPEG", "backup/2021/03/01/a/b.jpg"},
+		{"/old/c.txt", "/backup/2021/03/01/c.txt"},
+		{"new/d.jpeg", "backup/2021/03/01/new/d.jpg"},
+	}
+	for _, v := range cases {
+		assert.Equal(t, v.expected, m.mapKey(v.input), v.input)
+	}
+
+	m, err = newKeyMapper(&model.Task{})
+	assert.NoError(t, err)
+	assert.False(t, m.enabled())
+	assert.Equal(t, "/a/B", m.mapKey("/a/B"))
+}
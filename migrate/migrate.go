@@ -34,11 +34,14 @@ import (
 	"github.com/yunify/qscamel/endpoint/cos"
 	"github.com/yunify/qscamel/endpoint/filelist"
 	"github.com/yunify/qscamel/endpoint/fs"
+	"github.com/yunify/qscamel/endpoint/ftp"
 	"github.com/yunify/qscamel/endpoint/gcs"
 	"github.com/yunify/qscamel/endpoint/hdfs"
+	"github.com/yunify/qscamel/endpoint/http"
 	"github.com/yunify/qscamel/endpoint/qingstor"
 	"github.com/yunify/qscamel/endpoint/qiniu"
 	"github.com/yunify/qscamel/endpoint/s3"
+	"github.com/yunify/qscamel/endpoint/sftp"
 	"github.com/yunify/qscamel/endpoint/upyun"
 	"github.com/yunify/qscamel/model"
 	"github.com/yunify/qscamel/utils"
@@ -46,6 +49,9 @@ import (
 
 var (
 	t *model.Task
+	// tl guards the statistics of t, which are updated by workers
+	// concurrently.
+	tl sync.Mutex
 
 	oc chan model.Object
 	jc chan *model.DirectoryObject
@@ -60,11 +66,27 @@ var (
 
 	pool *ants.Pool
 
+	fl *filter
+	km *keyMapper
+
+	gt *gate
+	sr []*scheduleRule
+
 	multipartBoundarySize int64
 )
 
 // Execute will execute migrate task.
 func Execute(ctx context.Context, close chan struct{}) (err error) {
+	err = prepare(ctx)
+	if err != nil {
+		return
+	}
+
+	return run(ctx, close)
+}
+
+// prepare will load task and initialize everything for it.
+func prepare(ctx context.Context) (err error) {
 	t, err = model.GetTask(ctx)
 	if err != nil {
 		return
@@ -80,6 +102,32 @@ func Execute(ctx context.Context, close chan struct{}) (err error) {
 
 	rl = ratelimit.New(t.RateLimit)
 
+	tbl = newBandwidthLimiter(t.BandwidthLimit)
+	sbl = newBandwidthLimiter(t.Src.BandwidthLimit)
+	dbl = newBandwidthLimiter(0)
+	if t.Dst != nil {
+		dbl = newBandwidthLimiter(t.Dst.BandwidthLimit)
+	}
+
+	fl, err = newFilter(t)
+	if err != nil {
+		logrus.Errorf("New migrate filter failed for %v.", err)
+		return
+	}
+
+	km, err = newKeyMapper(t)
+	if err != nil {
+		logrus.Errorf("New migrate key mapper failed for %v.", err)
+		return
+	}
+
+	gt = newGate(contexts.Config.Concurrency)
+	sr, err = newSchedule(t)
+	if err != nil {
+		logrus.Errorf("New migrate schedule failed for %v.", err)
+		return
+	}
+
 	var workers int
 	if t.Workers == 0 {
 		workers = 100
@@ -97,8 +145,7 @@ func Execute(ctx context.Context, close chan struct{}) (err error) {
 		logrus.Errorf("Pre migrate check failed for %v.", err)
 		return
 	}
-
-	return run(ctx, close)
+	return
 }
 
 func check(ctx context.Context) (err error) {
@@ -159,14 +206,49 @@ func check(ctx context.Context) (err error) {
 		if err != nil {
 			return
 		}
+	case constants.EndpointSFTP:
+		src, err = sftp.New(ctx, constants.SourceEndpoint, contexts.Client)
+		if err != nil {
+			return
+		}
+	case constants.EndpointFTP:
+		src, err = ftp.New(ctx, constants.SourceEndpoint, contexts.Client)
+		if err != nil {
+			return
+		}
+	case constants.EndpointHTTP:
+		src, err = http.New(ctx, constants.SourceEndpoint, contexts.Client)
+		if err != nil {
+			return
+		}
 	default:
 		logrus.Errorf("Type src %s is not supported.", t.Src.Type)
 		err = constants.ErrEndpointNotSupported
 		return
 	}
 
+	// Delete task only works on source.
+	if t.Type == constants.TaskTypeDelete {
+		return
+	}
+	if t.Dst == nil {
+		logrus.Errorf("Task %s's destination is not set.", t.Name)
+		err = constants.ErrTaskInvalid
+		return
+	}
+
 	// Initialize destination.
 	switch t.Dst.Type {
+	case constants.EndpointAliyun:
+		dst, err = aliyun.New(ctx, constants.DestinationEndpoint, contexts.Client)
+		if err != nil {
+			return
+		}
+	case constants.EndpointAzblob:
+		dst, err = azblob.New(ctx, constants.DestinationEndpoint, contexts.Client)
+		if err != nil {
+			return
+		}
 	case constants.EndpointQingStor:
 		dst, err = qingstor.New(ctx, constants.DestinationEndpoint, contexts.Client)
 		if err != nil {
@@ -182,6 +264,26 @@ func check(ctx context.Context) (err error) {
 		if err != nil {
 			return
 		}
+	case constants.EndpointCOS:
+		dst, err = cos.New(ctx, constants.DestinationEndpoint, contexts.Client)
+		if err != nil {
+			return
+		}
+	case constants.EndpointGCS:
+		dst, err = gcs.New(ctx, constants.DestinationEndpoint, contexts.Client)
+		if err != nil {
+			return
+		}
+	case constants.EndpointHDFS:
+		dst, err = hdfs.New(ctx, constants.DestinationEndpoint, contexts.Client)
+		if err != nil {
+			return
+		}
+	case constants.EndpointSFTP:
+		dst, err = sftp.New(ctx, constants.DestinationEndpoint, contexts.Client)
+		if err != nil {
+			return
+		}
 	default:
 		logrus.Errorf("Type dst %s is not supported.", t.Dst.Type)
 		err = constants.ErrEndpointNotSupported
@@ -199,8 +301,27 @@ func run(ctx context.Context, close chan struct{}) (err error) {
 		return
 	}
 
+	if t.StartedAt == 0 {
+		t.StartedAt = time.Now().Unix()
+		err = saveTask(ctx)
+		if err != nil {
+			logrus.Errorf("Task %s save failed for %v.", t.Name, err)
+			return
+		}
+	}
+
 	go printStatistics(close)
 
+	// Workers started here will exit with task, and gate will be stopped
+	// once task interrupted so that no more objects will be handled.
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go scheduleWorker(wctx.Done())
+	go func() {
+		<-wctx.Done()
+		gt.stop()
+	}()
+
 	switch t.Type {
 	case constants.TaskTypeCopy:
 		t.Handle = copyObject
@@ -209,7 +330,6 @@ func run(ctx context.Context, close chan struct{}) (err error) {
 			return
 		}
 	case constants.TaskTypeDelete:
-		t.Handle = deleteObject
 		err = deleteTask(ctx)
 		if err != nil {
 			return
@@ -220,6 +340,24 @@ func run(ctx context.Context, close chan struct{}) (err error) {
 		if err != nil {
 			return
 		}
+	case constants.TaskTypeMove:
+		t.Handle = moveObject
+		err = moveTask(ctx)
+		if err != nil {
+			return
+		}
+	case constants.TaskTypeVerify:
+		t.Handle = verifyObject
+		err = verifyTask(ctx)
+		if err != nil {
+			return
+		}
+	case constants.TaskTypeSync:
+		t.Handle = copyObject
+		err = syncTask(ctx)
+		if err != nil {
+			return
+		}
 	default:
 		logrus.Errorf("Task %s's type %s is not supported.", t.Name, t.Type)
 		return
@@ -227,7 +365,8 @@ func run(ctx context.Context, close chan struct{}) (err error) {
 
 	// Update task status.
 	t.Status = constants.TaskStatusFinished
-	err = t.Save(ctx)
+	t.FinishedAt = time.Now().Unix()
+	err = saveTask(ctx)
 	if err != nil {
 		logrus.Errorf("Task %s save failed for %v.", t.Name, err)
 		return
@@ -245,70 +384,129 @@ func migrateWorker(ctx context.Context) {
 	defer utils.Recover()
 
 	for o := range oc {
-		ok, err := checkObject(ctx, o)
-		if err != nil {
-			logrus.Errorf("Check object failed for %v.", err)
-			continue
-		}
-		if ok {
-			err = model.DeleteObject(ctx, o)
-			if err != nil {
-				utils.CheckClosedDB(err)
-			}
-			continue
-		}
-
-		// Object may be tried in three times.
-		bo := backoff.NewExponentialBackOff()
-		bo.Multiplier = 2.0
-		backOff := backoff.WithMaxTries(bo, 10)
-
-		fn := func() error {
-			rl.Take()
-
-			err = t.Handle(ctx, o)
-			if err == nil {
-				return nil
-			}
+		migrateObject(ctx, o)
+	}
+}
 
-			switch x := o.(type) {
-			case *model.SingleObject:
-				t.FailedObjects[x.Key] = 0
-			}
+// migrateObject will handle an object with task's handle, object will be
+// kept in db if task interrupted so that it can be handled while resuming.
+func migrateObject(ctx context.Context, o model.Object) {
+	// Wait for schedule allows.
+	if !gt.acquire() {
+		return
+	}
+	defer gt.release()
 
-			logrus.Infof("%s object failed for %v, retried.", t.Type, err)
-			return err
+	if x, ok := o.(*model.SingleObject); ok {
+		ok, err := statListedObject(ctx, x)
+		// Object will be handled again while resuming.
+		if err != nil && ctx.Err() != nil {
+			return
 		}
-
-		err = backoff.Retry(fn, backOff)
 		if err != nil {
-			switch o.(type) {
-			case *model.SingleObject:
-				e := model.DeleteObject(ctx, o)
-				if e != nil {
-					utils.CheckClosedDB(e)
-					continue
-				}
+			recordFailure(ctx, x, withPhase(constants.FailurePhaseRead, err), 0)
+		}
+		if err != nil || !ok {
+			e := model.DeleteObject(ctx, o)
+			if e != nil {
+				utils.CheckClosedDB(e)
 			}
-			logrus.Errorf("%s object failed for %v.", t.Type, err)
-			continue
+			return
 		}
+	}
 
+	ok, err := checkObject(ctx, o)
+	if err != nil {
+		logrus.Errorf("Check object failed for %v.", err)
+		if x, ok := o.(*model.SingleObject); ok {
+			recordFailure(ctx, x, withPhase(constants.FailurePhaseCheck, err), 0)
+		}
+		return
+	}
+	if ok {
 		err = model.DeleteObject(ctx, o)
 		if err != nil {
 			utils.CheckClosedDB(err)
-			continue
+		}
+		if x, ok := o.(*model.SingleObject); ok {
+			clearFailure(ctx, x.Key)
+		}
+		return
+	}
+
+	// Object may be tried in three times.
+	bo := backoff.NewExponentialBackOff()
+	bo.Multiplier = 2.0
+	backOff := backoff.WithContext(backoff.WithMaxTries(bo, 10), ctx)
+
+	retries := 0
+	fn := func() error {
+		rl.Take()
+
+		err = t.Handle(ctx, o)
+		if err == nil {
+			return nil
+		}
+		// Failure caused by interruption should not be recorded.
+		if ctx.Err() != nil {
+			return backoff.Permanent(err)
 		}
 
 		switch x := o.(type) {
 		case *model.SingleObject:
-			if _, ok := t.FailedObjects[x.Key]; ok {
-				delete(t.FailedObjects, x.Key)
+			recordFailure(ctx, x, err, retries)
+		}
+		retries++
+
+		logrus.Infof("%s object failed for %v, retried.", t.Type, err)
+		return err
+	}
+
+	err = backoff.Retry(fn, backOff)
+	if err != nil && ctx.Err() != nil {
+		logrus.Infof("%s object interrupted for %v.", t.Type, err)
+		return
+	}
+	if err != nil {
+		switch o.(type) {
+		case *model.SingleObject:
+			e := model.DeleteObject(ctx, o)
+			if e != nil {
+				utils.CheckClosedDB(e)
+				return
 			}
-			t.SuccessCount++
-			t.SuccessSize += x.Size
 		}
+		logrus.Errorf("%s object failed for %v.", t.Type, err)
+		return
+	}
+
+	err = model.DeleteObject(ctx, o)
+	if err != nil {
+		utils.CheckClosedDB(err)
+		return
+	}
+
+	switch x := o.(type) {
+	case *model.SingleObject:
+		clearFailure(ctx, x.Key)
+		recordSuccess(x)
+	}
+}
+
+// retryTask will call fn until it succeeds or task interrupted.
+func retryTask(ctx context.Context, fn func() error) (err error) {
+	bo := backoff.WithContext(&backoff.ZeroBackOff{}, ctx)
+
+	err = backoff.Retry(func() error {
+		if ctx.Err() != nil {
+			return backoff.Permanent(ctx.Err())
+		}
+		return fn()
+	}, bo)
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
+	return
 }
 
 // isFinished will check whether current task has been finished.
@@ -349,11 +547,13 @@ func printStatistics(close chan struct{}) {
 	for {
 		select {
 		case <-close:
+			tl.Lock()
 			logrus.Infof("====Final Success Count: %d  Final Success Size: %d====", t.SuccessCount, t.SuccessSize)
 			filenames := make([]string, 0)
-			for name, _ := range t.FailedObjects {
+			for name := range t.FailedObjects {
 				filenames = append(filenames, name)
 			}
+			tl.Unlock()
 			if len(filenames) > 0 {
 				logrus.Infof("====Final Failed Count: %d  Final Failed filename: %v====", len(filenames), filenames)
 			} else {
@@ -361,16 +561,34 @@ func printStatistics(close chan struct{}) {
 			}
 			break
 		case <-timer.C:
+			tl.Lock()
 			if tmpCount != t.SuccessCount {
 				logrus.Infof("====Success Count: %d  Success Size: %d====", t.SuccessCount, t.SuccessSize)
 				tmpCount = t.SuccessCount
 			}
+			tl.Unlock()
 		}
 	}
 }
 
 func SaveTask() {
+	if t == nil {
+		return
+	}
+
+	tl.Lock()
+	defer tl.Unlock()
+
 	if t.SuccessCount != 0 || len(t.FailedObjects) > 0 {
 		_ = t.Save(nil)
 	}
 }
+
+// saveTask will save t, statistics updated by workers will be kept
+// consistent while saving.
+func saveTask(ctx context.Context) error {
+	tl.Lock()
+	defer tl.Unlock()
+
+	return t.Save(ctx)
+}
@@ -0,0 +1,80 @@
+package migrate
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/model"
+)
+
+// CanMove will return whether qscamel can move between the src and dst.
+func CanMove() bool {
+	if !CanCopy() {
+		return false
+	}
+	// If src isn't deletable, can't move.
+	if !src.Deletable() {
+		return false
+	}
+	return true
+}
+
+// Move will do move job between src and dst.
+func Move(ctx context.Context) (err error) {
+	oc = make(chan model.Object, contexts.Config.Concurrency*2)
+	jc = make(chan *model.DirectoryObject)
+
+	owg = &sync.WaitGroup{}
+	jwg = &sync.WaitGroup{}
+
+	// Wait for all object finished.
+	defer owg.Wait()
+	// Close channel for no more object.
+	defer close(oc)
+	// Close channel for no more job.
+	defer close(jc)
+	// Wait for all job finished.
+	defer jwg.Wait()
+
+	go listWorker(ctx)
+
+	for i := 0; i < contexts.Config.Concurrency; i++ {
+		owg.Add(1)
+		go migrateWorker(ctx)
+	}
+
+	err = List(ctx)
+	if err != nil {
+		logrus.Errorf("List failed for %v.", err)
+		return err
+	}
+
+	return
+}
+
+// moveTask will execute a move task.
+func moveTask(ctx context.Context) (err error) {
+	if !CanMove() {
+		logrus.Infof("Source type %s and destination type %s not support move.",
+			t.Src.Type, t.Dst.Type)
+		return
+	}
+	logrus.Debugf("Start move task.")
+
+	return retryTask(ctx, func() error {
+		err := Move(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !isFinished(ctx) {
+			return constants.ErrTaskNotFinished
+		}
+
+		return nil
+	})
+}
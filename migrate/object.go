@@ -22,12 +22,35 @@ func listObject(ctx context.Context, j *model.DirectoryObject) (err error) {
 	defer utils.Recover()
 
 	srcName := src.Name(ctx)
-	dstName := dst.Name(ctx)
+	// Delete task doesn't have dst.
+	dstName := ""
+	if dst != nil {
+		dstName = dst.Name(ctx)
+	}
+
+	// Objects listed lazily will be stat and filtered while handling.
+	lazy := listLazily()
+
+	// Objects listed from src should not be deleted by sync task.
+	var dctx context.Context
+	if t.Type == constants.TaskTypeSync {
+		dctx = model.NewDestinationContext(ctx)
+	}
+
 	err = src.List(ctx, j, func(o model.Object) {
 		defer utils.Recover()
 
+		// Objects will be listed again while resuming.
+		if ctx.Err() != nil {
+			return
+		}
+
 		switch x := o.(type) {
 		case *model.DirectoryObject:
+			if !fl.matchDirectory(x.Key) {
+				logrus.Debugf("Directory object %s is filtered.", x.Key)
+				return
+			}
 			err = model.CreateObject(ctx, x)
 			if err != nil {
 				utils.CheckClosedDB(err)
@@ -36,6 +59,42 @@ func listObject(ctx context.Context, j *model.DirectoryObject) (err error) {
 			logrus.Debugf("Directory object %s created.", x.Key)
 			return
 		case *model.SingleObject:
+			if dctx != nil {
+				err = model.DeleteObject(dctx, &model.SingleObject{Key: syncKey(x.Key)})
+				if err != nil {
+					utils.CheckClosedDB(err)
+				}
+			}
+			// Last modified may be not returned while listing.
+			if !lazy && fl.needModified() && x.LastModified == 0 && !x.IsDir {
+				so, err := src.Stat(ctx, x.Key, x.IsDir)
+				// Objects will be listed again while resuming.
+				if err != nil && ctx.Err() != nil {
+					return
+				}
+				if err != nil {
+					logrus.Errorf("Src stat %s failed for %v.", x.Key, err)
+					recordFailure(ctx, x, withPhase(constants.FailurePhaseCheck, err), 0)
+					return
+				}
+				if so == nil {
+					return
+				}
+				x.LastModified = so.LastModified
+			}
+			if (lazy && !fl.matchKey(x.Key)) || (!lazy && !fl.matchObject(x)) {
+				logrus.Debugf("Single object %s is filtered.", x.Key)
+				return
+			}
+			ok, err := checkKeyMapping(ctx, x.Key)
+			if err != nil {
+				utils.CheckClosedDB(err)
+				return
+			}
+			if !ok {
+				recordFailure(ctx, x, withPhase(constants.FailurePhaseCheck, constants.ErrObjectKeyMappingInvalid), 0)
+				return
+			}
 			if x.IsDir &&
 				(!strings.Contains(srcName, "qingstor") && !strings.Contains(srcName, "s3")) &&
 				(!strings.Contains(dstName, "qingstor") && !strings.Contains(dstName, "s3")) {
@@ -54,6 +113,10 @@ func listObject(ctx context.Context, j *model.DirectoryObject) (err error) {
 		logrus.Errorf("Src list failed for %v.", err)
 		return
 	}
+	// Job interrupted should be listed again while resuming.
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
 
 	err = model.DeleteObject(ctx, j)
 	if err != nil {
@@ -67,9 +130,17 @@ func checkObject(ctx context.Context, mo model.Object) (ok bool, err error) {
 	if (t.IgnoreExisting == "" && t.IgnoreBeforeTimestamp == 0) || mo.Type() == constants.ObjectTypePartial {
 		return false, nil
 	}
+	// Verify task should always compare objects, and move task will check
+	// objects by itself.
+	if t.Type == constants.TaskTypeVerify || t.Type == constants.TaskTypeMove {
+		return false, nil
+	}
 
-	o := mo.(*model.SingleObject)
+	return compareObject(ctx, mo.(*model.SingleObject))
+}
 
+// compareObject will tell whether an object in dst is the same as src.
+func compareObject(ctx context.Context, o *model.SingleObject) (ok bool, err error) {
 	logrus.Infof("Start checking object %s.", o.Key)
 
 	so, err := statObject(ctx, src, o, false)
@@ -80,7 +151,7 @@ func checkObject(ctx context.Context, mo model.Object) (ok bool, err error) {
 		return true, nil
 	}
 
-	do, err := statObject(ctx, dst, o, false)
+	do, err := statObject(ctx, dst, dstObject(o), false)
 	if err != nil {
 		return
 	}
@@ -156,12 +227,16 @@ func checkObjectAfterMigrate(ctx context.Context, mo model.Object) (err error) {
 		return err
 	}
 
-	rdo, err := statObject(ctx, dst, o, true)
+	rdo, err := statObject(ctx, dst, dstObject(o), true)
 	if err != nil {
 		logrus.Errorf("Dst stat %s failed for %v.", o.Key, err)
 		return err
 	}
 
+	if rso == nil || rdo == nil {
+		logrus.Errorf("Object %s is not found in src or dst.", o.Key)
+		return constants.ErrObjectMismatch
+	}
 	if rdo.MD5 != rso.MD5 {
 		logrus.Errorf("md5 mismatch between src and dst %s.", o.Key)
 		return fmt.Errorf("md5 not match")
@@ -173,27 +248,40 @@ func checkObjectAfterMigrate(ctx context.Context, mo model.Object) (err error) {
 // copyObject will do a real copy.
 func copyObject(ctx context.Context, o model.Object) (err error) {
 	so := o.(*model.SingleObject)
+	dk := km.mapKey(so.Key)
 
 	logrus.Infof("Start copying object %s.", so.Key)
 
 	// Upload single object, if don't to split it.
 	if so.Size <= multipartBoundarySize || !dst.Partable() {
-		r, err := src.Read(ctx, so.Key, so.IsDir)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// Single object which is being written should be finished even if
+		// task interrupted.
+		ctx := utils.NewDetachedContext(ctx)
+
+		sr, err := src.Read(ctx, so.Key, so.IsDir)
 		if err != nil {
 			logrus.Errorf("Src read %s failed for %v.", so.Key, err)
-			return err
+			return withPhase(constants.FailurePhaseRead, err)
 		}
-		err = dst.Write(ctx, so.Key, so.Size, r, so.IsDir, so.QSMetadata)
+		// Reader should be closed even if dst stops reading early, or the
+		// connection of src will be leaked.
+		r := throttle(ctx, sr, tbl, sbl, dbl)
+		defer r.Close()
+
+		err = dst.Write(ctx, dk, so.Size, r, so.IsDir, so.QSMetadata)
 		if err != nil {
 			logrus.Errorf("Dst write %s failed for %v.", so.Key, err)
-			return err
+			return withPhase(constants.FailurePhaseWrite, err)
 		}
 
 		if t.CheckMD5 {
 			err = checkObjectAfterMigrate(ctx, o)
 			if err != nil {
-				_ = dst.Delete(ctx, so.Key)
-				return err
+				_ = dst.Delete(ctx, dk)
+				return withPhase(constants.FailurePhaseCheck, err)
 			}
 		}
 
@@ -202,10 +290,10 @@ func copyObject(ctx context.Context, o model.Object) (err error) {
 	}
 
 	// Split single object into part objects.
-	uploadID, partSize, partNumbers, err := dst.InitPart(ctx, so.Key, so.Size, so.QSMetadata)
+	uploadID, partSize, partNumbers, err := dst.InitPart(ctx, dk, so.Size, so.QSMetadata)
 	if err != nil {
 		logrus.Errorf("Dst init part %s failed for %v.", so.Key, err)
-		return err
+		return withPhase(constants.FailurePhaseWrite, err)
 	}
 
 	var e error
@@ -220,6 +308,16 @@ func copyObject(ctx context.Context, o model.Object) (err error) {
 
 		offset := int64(0)
 		for i := 0; i < partNumbers; i++ {
+			// Stop submitting parts if task interrupted, running parts will
+			// be waited before the upload aborted.
+			if ctx.Err() != nil {
+				once.Do(func() {
+					logrus.Infof("Copy partial object %s interrupted.", so.Key)
+					e = ctx.Err()
+				})
+				break
+			}
+
 			wg.Add(1)
 
 			oo := &model.PartialObject{
@@ -243,23 +341,29 @@ func copyObject(ctx context.Context, o model.Object) (err error) {
 
 				logrus.Infof("Start copying partial object %s at %d.", oo.Key, oo.PartNumber)
 
-				r, err := src.ReadRange(ctx, oo.Key, oo.Offset, oo.Size)
+				sr, err := src.ReadRange(ctx, oo.Key, oo.Offset, oo.Size)
 				if err != nil {
 					once.Do(func() {
 						logrus.Errorf("Src read partial object %s at %d failed for %v.",
 							oo.Key, oo.Offset, err)
 						close(eQuit)
-						e = err
+						e = withPhase(constants.FailurePhaseRead, err)
 					})
 					return
 				}
-				err = dst.UploadPart(ctx, oo, r)
+				r := throttle(ctx, sr, tbl, sbl, dbl)
+				defer r.Close()
+
+				// Part should be uploaded with dst key.
+				do := *oo
+				do.Key = dk
+				err = dst.UploadPart(ctx, &do, r)
 				if err != nil {
 					once.Do(func() {
 						logrus.Errorf("Dst write partial object %s at %d failed for %v.",
 							oo.Key, oo.Offset, err)
 						close(eQuit)
-						e = err
+						e = withPhase(constants.FailurePhaseWrite, err)
 					})
 					return
 				}
@@ -272,7 +376,7 @@ func copyObject(ctx context.Context, o model.Object) (err error) {
 				once.Do(func() {
 					logrus.Errorf("Submit Upload partial object %s request failed for %v", so.Key, err)
 					close(eQuit)
-					e = err
+					e = withPhase(constants.FailurePhaseWrite, err)
 				})
 				return
 			}
@@ -289,25 +393,26 @@ func copyObject(ctx context.Context, o model.Object) (err error) {
 	}
 
 	if e != nil {
-		err = dst.AbortUploads(ctx, so.Key, uploadID)
+		// Abort should be done even if task interrupted.
+		err = dst.AbortUploads(utils.NewDetachedContext(ctx), dk, uploadID)
 		if err != nil {
 			logrus.Errorf("Abort partial object %s failed for %v", so.Key, err)
 		}
 		return e
 	}
 
-	err = dst.CompleteParts(ctx, so.Key, uploadID, partNumbers)
+	err = dst.CompleteParts(ctx, dk, uploadID, partNumbers)
 	if err != nil {
 		logrus.Errorf("Complete partial object %s failed for %v", so.Key, err)
-		return err
+		return withPhase(constants.FailurePhaseComplete, err)
 	}
 
 	if t.CheckMD5 {
 		if t.CheckMD5 {
 			err = checkObjectAfterMigrate(ctx, o)
 			if err != nil {
-				_ = dst.Delete(ctx, so.Key)
-				return err
+				_ = dst.Delete(ctx, dk)
+				return withPhase(constants.FailurePhaseCheck, err)
 			}
 		}
 	}
@@ -317,6 +422,55 @@ func copyObject(ctx context.Context, o model.Object) (err error) {
 	return
 }
 
+// moveObject will copy an object and delete it from src after verified.
+func moveObject(ctx context.Context, o model.Object) (err error) {
+	so, ok := o.(*model.SingleObject)
+	if !ok {
+		logrus.Errorf("Object is invalid for move.")
+		return constants.ErrObjectInvalid
+	}
+
+	// Object which is already the same in dst doesn't need to be copied again.
+	if t.IgnoreExisting != "" || t.IgnoreBeforeTimestamp != 0 {
+		ok, err = compareObject(ctx, so)
+		if err != nil {
+			return withPhase(constants.FailurePhaseCheck, err)
+		}
+	}
+	if !ok {
+		err = copyObject(ctx, o)
+		if err != nil {
+			return
+		}
+		// copyObject has checked md5 if check md5 is enabled.
+		if !t.CheckMD5 {
+			err = checkObjectAfterMigrate(ctx, o)
+			if err != nil {
+				return withPhase(constants.FailurePhaseCheck, err)
+			}
+		}
+	}
+
+	// Make sure object exists in dst before delete it from src.
+	do, err := statObject(ctx, dst, dstObject(so), false)
+	if err != nil {
+		return withPhase(constants.FailurePhaseCheck, err)
+	}
+	if do == nil || (!so.IsDir && do.Size != so.Size) {
+		logrus.Errorf("Object %s is not match in dst, skip deleting from src.", so.Key)
+		return withPhase(constants.FailurePhaseCheck, constants.ErrObjectMismatch)
+	}
+
+	err = src.Delete(ctx, so.Key)
+	if err != nil {
+		logrus.Errorf("Src delete %s failed for %v.", so.Key, err)
+		return withPhase(constants.FailurePhaseDelete, err)
+	}
+
+	logrus.Infof("Object %s moved.", so.Key)
+	return
+}
+
 // deleteObject will do a real delete.
 func deleteObject(ctx context.Context, o model.Object) (err error) {
 	switch x := o.(type) {
@@ -326,12 +480,14 @@ func deleteObject(ctx context.Context, o model.Object) (err error) {
 		err = dst.Delete(ctx, x.Key)
 		if err != nil {
 			logrus.Errorf("Dst delete %s failed for %v.", x.Key, err)
-			return err
+			return withPhase(constants.FailurePhaseDelete, err)
 		}
 
 		logrus.Infof("Single object %s deleted.", x.Key)
 	case *model.PartialObject:
-		// TODO: we should handle delete partial object here.
+		logrus.Errorf("Object %s is invalid for delete.", x.Key)
+		err = constants.ErrObjectInvalid
+		return
 	}
 
 	return
@@ -346,12 +502,12 @@ func fetchObject(ctx context.Context, o model.Object) (err error) {
 		url, err := src.Reach(ctx, x.Key)
 		if err != nil {
 			logrus.Errorf("Src reach %s failed for %v.", x.Key, err)
-			return err
+			return withPhase(constants.FailurePhaseRead, err)
 		}
-		err = dst.Fetch(ctx, x.Key, url)
+		err = dst.Fetch(ctx, km.mapKey(x.Key), url)
 		if err != nil {
 			logrus.Errorf("Dst fetch %s failed for %v.", x.Key, err)
-			return err
+			return withPhase(constants.FailurePhaseWrite, err)
 		}
 
 		logrus.Infof("Single object %s fetched.", x.Key)
@@ -364,6 +520,104 @@ func fetchObject(ctx context.Context, o model.Object) (err error) {
 	return
 }
 
+// verifyObject will compare an object between src and dst.
+func verifyObject(ctx context.Context, o model.Object) (err error) {
+	so, ok := o.(*model.SingleObject)
+	if !ok {
+		logrus.Errorf("Object is invalid for verify.")
+		return constants.ErrObjectInvalid
+	}
+
+	logrus.Infof("Start verifying object %s.", so.Key)
+
+	rso, err := statObject(ctx, src, so, false)
+	if err != nil {
+		return withPhase(constants.FailurePhaseCheck, err)
+	}
+	if rso == nil {
+		logrus.Infof("Object %s has been deleted from src, ignore.", so.Key)
+		return model.DeleteReport(ctx, so.Key)
+	}
+
+	r := &model.Report{
+		Key:     so.Key,
+		SrcSize: rso.Size,
+	}
+
+	rdo, err := statObject(ctx, dst, dstObject(so), false)
+	if err != nil {
+		return withPhase(constants.FailurePhaseCheck, err)
+	}
+	if rdo == nil {
+		logrus.Infof("Object %s is missing at dst.", so.Key)
+		r.Type = constants.ReportTypeMissing
+		return model.CreateReport(ctx, r)
+	}
+
+	r.DstSize = rdo.Size
+	if rso.Size != rdo.Size {
+		logrus.Infof("Object %s size is not match.", so.Key)
+		r.Type = constants.ReportTypeSizeMismatch
+		return model.CreateReport(ctx, r)
+	}
+
+	if t.CheckMD5 {
+		r.SrcMD5, r.DstMD5 = rso.MD5, rdo.MD5
+		if len(r.SrcMD5) != 32 {
+			r.SrcMD5, err = md5SumObject(ctx, src, so)
+			if err != nil {
+				return withPhase(constants.FailurePhaseRead, err)
+			}
+		}
+		if len(r.DstMD5) != 32 {
+			r.DstMD5, err = md5SumObject(ctx, dst, dstObject(so))
+			if err != nil {
+				return withPhase(constants.FailurePhaseRead, err)
+			}
+		}
+		if r.SrcMD5 != r.DstMD5 {
+			logrus.Infof("Object %s md5 is not match.", so.Key)
+			r.Type = constants.ReportTypeMD5Mismatch
+			return model.CreateReport(ctx, r)
+		}
+	}
+
+	logrus.Infof("Object %s verified.", so.Key)
+	return model.DeleteReport(ctx, so.Key)
+}
+
+// listLazily will return whether objects listed from src need to be stat
+// before handling.
+func listLazily() bool {
+	e, ok := src.(endpoint.LazyLister)
+	return ok && e.ListLazily()
+}
+
+// statListedObject will fill the metadata of object o which is listed from
+// src lazily, ok will be false if o is not found in src or filtered.
+func statListedObject(ctx context.Context, o *model.SingleObject) (ok bool, err error) {
+	if !listLazily() {
+		return true, nil
+	}
+
+	so, err := src.Stat(ctx, o.Key, o.IsDir)
+	if err != nil {
+		logrus.Errorf("Src stat %s failed for %v.", o.Key, err)
+		return
+	}
+	if so == nil {
+		logrus.Warnf("Object %s is not found in src, skipped.", o.Key)
+		return
+	}
+	o.Size, o.LastModified, o.MD5 = so.Size, so.LastModified, so.MD5
+
+	if !fl.matchObject(o) {
+		logrus.Debugf("Single object %s is filtered.", o.Key)
+		return
+	}
+	return true, nil
+}
+
 // statObject will get an object metadata and try to get it's md5 if available.
 func statObject(
 	ctx context.Context, e endpoint.Base, o *model.SingleObject, isMD5 bool,
@@ -415,8 +669,11 @@ func md5SumObject(
 		}
 	}
 
+	tr := throttle(ctx, r, tbl, endpointLimiter(e))
+	defer tr.Close()
+
 	h := md5.New()
-	if _, err := io.Copy(h, r); err != nil {
+	if _, err := io.Copy(h, tr); err != nil {
 		return "", err
 	}
 	sum := h.Sum(nil)
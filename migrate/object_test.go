@@ -0,0 +1,84 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/ratelimit"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/db"
+	"github.com/yunify/qscamel/endpoint"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// lazyLister will only return objects in objects while stat, and fail the
+// keys in fails.
+type lazyLister struct {
+	endpoint.Source
+
+	objects map[string]*model.SingleObject
+	fails   map[string]bool
+}
+
+func (l *lazyLister) Stat(ctx context.Context, p string, isDir bool) (o *model.SingleObject, err error) {
+	if l.fails[p] {
+		return nil, errors.New("stat failed")
+	}
+	return l.objects[p], nil
+}
+
+func (l *lazyLister) ListLazily() bool {
+	return true
+}
+
+func TestMigrateObject_Lazy(tt *testing.T) {
+	database, _ := db.NewDB(&db.DatabaseOptions{InMemory: true})
+	contexts.DB = database
+	defer func() {
+		database.Close()
+		contexts.DB = nil
+	}()
+
+	handled := make(map[string]int64)
+	t = &model.Task{
+		Type:          constants.TaskTypeCopy,
+		MinSize:       2,
+		FailedObjects: make(map[string]int),
+		Handle: func(ctx context.Context, o model.Object) error {
+			so := o.(*model.SingleObject)
+			handled[so.Key] = so.Size
+			return nil
+		},
+	}
+	src = &lazyLister{
+		objects: map[string]*model.SingleObject{
+			"a": {Key: "a", Size: 4},
+			"b": {Key: "b", Size: 1},
+		},
+		fails: map[string]bool{"c": true},
+	}
+	rl = ratelimit.NewUnlimited()
+	gt = newGate(1)
+	fl, _ = newFilter(t)
+
+	ctx := utils.NewTaskContext(context.Background(), "test")
+	for _, v := range []string{"a", "b", "c", "d"} {
+		migrateObject(ctx, &model.SingleObject{Key: v})
+	}
+
+	// Only objects found and matched after stat should be handled.
+	assert.Equal(tt, map[string]int64{"a": 4}, handled)
+	assert.Equal(tt, int64(1), t.SuccessCount)
+	assert.Equal(tt, int64(4), t.SuccessSize)
+	assert.Equal(tt, map[string]int{"c": 0}, t.FailedObjects)
+
+	f, err := model.NextFailure(ctx, "")
+	assert.NoError(tt, err)
+	assert.Equal(tt, "c", f.Key)
+	assert.Equal(tt, constants.FailurePhaseRead, f.Phase)
+}
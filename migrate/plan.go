@@ -0,0 +1,195 @@
+package migrate
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// PlanResult is the estimate of what a task would do.
+type PlanResult struct {
+	Type string
+
+	HandleCount int64
+	HandleSize  int64
+	// Multipart objects are also counted in handle.
+	MultipartCount int64
+	MultipartSize  int64
+	SkipCount      int64
+	SkipSize       int64
+	FailedCount    int64
+
+	// Only available for sync task.
+	DestinationCount int64
+	DeleteCount      int64
+}
+
+// Plan will list task's source and check objects without writing anything
+// to destination. Task's objects will be written to db, so it should be
+// used with a temporary db.
+func Plan(ctx context.Context) (r *PlanResult, err error) {
+	err = prepare(ctx)
+	if err != nil {
+		return
+	}
+
+	var ok bool
+	switch t.Type {
+	case constants.TaskTypeCopy:
+		ok = CanCopy()
+	case constants.TaskTypeVerify:
+		ok = true
+	case constants.TaskTypeDelete:
+		ok = CanDelete()
+	case constants.TaskTypeFetch:
+		ok = CanFetch()
+	case constants.TaskTypeMove:
+		ok = CanMove()
+	case constants.TaskTypeSync:
+		ok = CanSync()
+	default:
+		logrus.Errorf("Task %s's type %s is not supported.", t.Name, t.Type)
+		return nil, constants.ErrTaskInvalid
+	}
+	if !ok {
+		logrus.Errorf("Endpoints of task %s not support %s.", t.Name, t.Type)
+		return nil, constants.ErrEndpointNotSupported
+	}
+
+	r = &PlanResult{Type: t.Type}
+
+	if t.Type == constants.TaskTypeSync {
+		err = ListDestination(ctx)
+		if err != nil {
+			return
+		}
+	}
+
+	for {
+		err = plan(ctx, r)
+		if err != nil {
+			return
+		}
+		if isFinished(ctx) {
+			break
+		}
+	}
+
+	tl.Lock()
+	r.FailedCount += int64(len(t.FailedObjects))
+	tl.Unlock()
+	if t.Type == constants.TaskTypeSync {
+		r.DestinationCount = t.DestinationCount
+		r.DeleteCount, err = model.CountSingleObject(model.NewDestinationContext(ctx))
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// plan will do a pass of listing.
+func plan(ctx context.Context, r *PlanResult) (err error) {
+	oc = make(chan model.Object, contexts.Config.Concurrency*2)
+	jc = make(chan *model.DirectoryObject)
+
+	owg = &sync.WaitGroup{}
+	jwg = &sync.WaitGroup{}
+
+	// Wait for all object finished.
+	defer owg.Wait()
+	// Close channel for no more object.
+	defer close(oc)
+	// Close channel for no more job.
+	defer close(jc)
+	// Wait for all job finished.
+	defer jwg.Wait()
+
+	go listWorker(ctx)
+
+	for i := 0; i < contexts.Config.Concurrency; i++ {
+		owg.Add(1)
+		go planWorker(ctx, r)
+	}
+
+	err = List(ctx)
+	if err != nil {
+		logrus.Errorf("List failed for %v.", err)
+		return err
+	}
+
+	return
+}
+
+// planWorker will check objects and count them.
+func planWorker(ctx context.Context, r *PlanResult) {
+	defer owg.Done()
+	defer utils.Recover()
+
+	for o := range oc {
+		err := model.DeleteObject(ctx, o)
+		if err != nil {
+			utils.CheckClosedDB(err)
+		}
+
+		so, ok := o.(*model.SingleObject)
+		if !ok {
+			continue
+		}
+
+		ok, err = statListedObject(ctx, so)
+		if err != nil {
+			atomic.AddInt64(&r.FailedCount, 1)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		ok, err = planObject(ctx, so)
+		if err != nil {
+			logrus.Errorf("Check object %s failed for %v.", so.Key, err)
+			atomic.AddInt64(&r.FailedCount, 1)
+			continue
+		}
+		if ok {
+			atomic.AddInt64(&r.SkipCount, 1)
+			atomic.AddInt64(&r.SkipSize, so.Size)
+			continue
+		}
+
+		atomic.AddInt64(&r.HandleCount, 1)
+		atomic.AddInt64(&r.HandleSize, so.Size)
+
+		switch t.Type {
+		case constants.TaskTypeCopy, constants.TaskTypeMove, constants.TaskTypeSync:
+			if so.Size > multipartBoundarySize && dst.Partable() {
+				atomic.AddInt64(&r.MultipartCount, 1)
+				atomic.AddInt64(&r.MultipartSize, so.Size)
+			}
+		}
+	}
+}
+
+// planObject will tell whether an object would be skipped.
+func planObject(ctx context.Context, o *model.SingleObject) (ok bool, err error) {
+	switch t.Type {
+	case constants.TaskTypeDelete:
+		// Delete task doesn't have dst to check.
+		return false, nil
+	case constants.TaskTypeMove:
+		// Move task checks objects by itself.
+		if t.IgnoreExisting == "" && t.IgnoreBeforeTimestamp == 0 {
+			return false, nil
+		}
+		return compareObject(ctx, o)
+	}
+	return checkObject(ctx, o)
+}
@@ -0,0 +1,109 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// Retry will enqueue the failed objects of a task again and execute it.
+func Retry(ctx context.Context, close chan struct{}) (err error) {
+	err = prepare(ctx)
+	if err != nil {
+		return
+	}
+
+	if t.Status == constants.TaskStatusCreated {
+		logrus.Errorf("Task %s has not been started, run it instead.", t.Name)
+		return constants.ErrTaskNotStarted
+	}
+
+	n, err := requeueFailures(ctx, true)
+	if err != nil {
+		return
+	}
+	// Objects failed to be deleted from dst by sync task are stored with the
+	// destination context.
+	if t.Type == constants.TaskTypeSync {
+		m, err := requeueFailures(model.NewDestinationContext(ctx), false)
+		if err != nil {
+			return err
+		}
+		n += m
+	}
+	if n == 0 {
+		logrus.Infof("Task %s has no failed objects, skip.", t.Name)
+		return
+	}
+	logrus.Infof("%d failed objects of task %s have been enqueued.", n, t.Name)
+
+	t.Status = constants.TaskStatusRunning
+	t.FinishedAt = 0
+	err = saveTask(ctx)
+	if err != nil {
+		logrus.Errorf("Task %s save failed for %v.", t.Name, err)
+		return
+	}
+
+	return run(ctx, close)
+}
+
+// requeueFailures will create single objects for all failures in ctx, so
+// that they will be handled while task running. source should be true for
+// failures of objects listed from src.
+func requeueFailures(ctx context.Context, source bool) (n int64, err error) {
+	p := ""
+	for {
+		f, err := model.NextFailure(ctx, p)
+		if err != nil {
+			return n, err
+		}
+		if f == nil {
+			break
+		}
+		p = f.Key
+
+		o := f.Object
+		if o == nil && source && !listLazily() {
+			// Objects failed while listing don't have object info, which
+			// need to be stat again.
+			o, err = src.Stat(ctx, f.Key, false)
+			if err != nil {
+				logrus.Errorf("Src stat %s failed for %v.", f.Key, err)
+				continue
+			}
+			if o == nil {
+				logrus.Warnf("Object %s is not found in src, skipped.", f.Key)
+				continue
+			}
+		}
+		if o == nil {
+			o = &model.SingleObject{Key: f.Key}
+		}
+		// Objects mapped to conflicted keys should still be failed.
+		if source {
+			ok, err := checkKeyMapping(ctx, o.Key)
+			if err != nil {
+				utils.CheckClosedDB(err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		err = model.CreateObject(ctx, o)
+		if err != nil {
+			return n, err
+		}
+		tl.Lock()
+		t.FailedObjects[o.Key] = f.Retries
+		tl.Unlock()
+		n++
+	}
+	return
+}
@@ -0,0 +1,106 @@
+package migrate
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// scheduleRule is a schedule rule with parsed window.
+type scheduleRule struct {
+	*model.ScheduleRule
+
+	window *utils.Cron
+}
+
+// newSchedule will parse task's schedule rules, task should have been
+// checked.
+func newSchedule(t *model.Task) (rs []*scheduleRule, err error) {
+	for _, v := range t.Schedule {
+		c, err := utils.ParseCron(v.Window)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, &scheduleRule{ScheduleRule: v, window: c})
+	}
+	return
+}
+
+// scheduleWorker will apply the first matched schedule rule until done.
+// Rule will only be applied when it changes, so that limits changed by
+// control command will be kept in the same window.
+func scheduleWorker(done <-chan struct{}) {
+	defer utils.Recover()
+
+	if len(sr) == 0 {
+		return
+	}
+
+	current := -1
+	apply := func(now time.Time) {
+		idx := len(sr)
+		for k, v := range sr {
+			if v.window.Match(now) {
+				idx = k
+				break
+			}
+		}
+		if idx == current {
+			return
+		}
+		current = idx
+
+		if idx == len(sr) {
+			applySchedule(nil)
+			return
+		}
+		applySchedule(sr[idx])
+	}
+
+	apply(time.Now())
+
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			apply(now)
+		}
+	}
+}
+
+// applySchedule will apply schedule rule r, nil means no rule matched and
+// task's own config will be used.
+func applySchedule(r *scheduleRule) {
+	bw := t.BandwidthLimit
+	n := contexts.Config.Concurrency
+	if r != nil {
+		if r.BandwidthLimit > 0 {
+			bw = r.BandwidthLimit
+		}
+		if r.Concurrency > 0 {
+			n = r.Concurrency
+		}
+		if r.Pause {
+			n = 0
+		}
+	}
+
+	tbl.SetLimit(bandwidthLimit(bw))
+	gt.setLimit(n)
+
+	switch {
+	case r == nil:
+		logrus.Infof("No schedule window matched, use bandwidth limit %d and concurrency %d.", bw, n)
+	case r.Pause:
+		logrus.Infof("Schedule window %s matched, task paused.", r.Window)
+	default:
+		logrus.Infof("Schedule window %s matched, use bandwidth limit %d and concurrency %d.", r.Window, bw, n)
+	}
+}
@@ -0,0 +1,284 @@
+package migrate
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/cenkalti/backoff"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/endpoint"
+	"github.com/yunify/qscamel/model"
+	"github.com/yunify/qscamel/utils"
+)
+
+// CanSync will return whether qscamel can sync between the src and dst.
+func CanSync() bool {
+	if !CanCopy() {
+		return false
+	}
+	// If dst isn't deletable, can't sync.
+	if !dst.Deletable() {
+		return false
+	}
+	// If dst can't be listed, can't sync.
+	if _, ok := dst.(endpoint.Source); !ok {
+		return false
+	}
+	return true
+}
+
+// ListDestination will list all objects in dst and store them in db.
+func ListDestination(ctx context.Context) (err error) {
+	if t.DestinationListed {
+		return
+	}
+
+	e := dst.(endpoint.Source)
+	dctx := model.NewDestinationContext(ctx)
+
+	j, err := model.GetDirectoryObject(dctx, "")
+	if err != nil {
+		return
+	}
+	if j == nil {
+		h, err := model.HasDirectoryObject(dctx)
+		if err != nil {
+			return err
+		}
+		// No directory object means dst listing has not been started.
+		if !h {
+			j = &model.DirectoryObject{}
+			err = model.CreateObject(dctx, j)
+			if err != nil {
+				return err
+			}
+		} else {
+			j, err = model.NextDirectoryObject(dctx, "")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Directory object's key always bigger than its parent, so we can list
+	// all of them in one pass.
+	for j != nil {
+		// Listing should also be paused by schedule.
+		if !gt.wait() {
+			return ctx.Err()
+		}
+
+		logrus.Infof("Start listing destination job %s.", j.Key)
+
+		err = e.List(dctx, j, func(o model.Object) {
+			switch x := o.(type) {
+			case *model.DirectoryObject:
+				if !fl.matchDirectory(x.Key) {
+					return
+				}
+				err := model.CreateObject(dctx, x)
+				if err != nil {
+					utils.CheckClosedDB(err)
+				}
+			case *model.SingleObject:
+				// Objects filtered from src should not be deleted.
+				if x.IsDir || !fl.matchKey(x.Key) {
+					return
+				}
+				x.Key = syncKey(x.Key)
+				err := model.CreateObject(dctx, x)
+				if err != nil {
+					utils.CheckClosedDB(err)
+				}
+			}
+		})
+		if err != nil {
+			logrus.Errorf("Dst list failed for %v.", err)
+			return
+		}
+		// Job interrupted should be listed again while resuming.
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err = model.DeleteObject(dctx, j)
+		if err != nil {
+			return
+		}
+
+		logrus.Infof("Destination job %s listed.", j.Key)
+
+		j, err = model.NextDirectoryObject(dctx, j.Key)
+		if err != nil {
+			return
+		}
+	}
+
+	t.DestinationCount, err = model.CountSingleObject(dctx)
+	if err != nil {
+		return
+	}
+	t.DestinationListed = true
+	err = saveTask(ctx)
+	if err != nil {
+		return
+	}
+
+	logrus.Infof("Destination listed, %d objects found.", t.DestinationCount)
+	return
+}
+
+// Purge will delete all objects that only exist in dst.
+func Purge(ctx context.Context) (err error) {
+	oc = make(chan model.Object, contexts.Config.Concurrency*2)
+
+	owg = &sync.WaitGroup{}
+
+	// Wait for all object finished.
+	defer owg.Wait()
+	// Close channel for no more object.
+	defer close(oc)
+
+	for i := 0; i < contexts.Config.Concurrency; i++ {
+		owg.Add(1)
+		go purgeWorker(ctx)
+	}
+
+	p := ""
+	for {
+		so, err := model.NextSingleObject(ctx, p)
+		if err != nil {
+			return err
+		}
+		if so == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		oc <- so
+		p = so.Key
+	}
+
+	return
+}
+
+// purgeWorker will delete objects from dst, ctx should be the destination
+// context.
+func purgeWorker(ctx context.Context) {
+	defer owg.Done()
+	defer utils.Recover()
+
+	for o := range oc {
+		// Wait for schedule allows, object will be kept in db if task
+		// interrupted.
+		if !gt.acquire() {
+			continue
+		}
+
+		bo := backoff.NewExponentialBackOff()
+		bo.Multiplier = 2.0
+
+		retries := -1
+		err := backoff.Retry(func() error {
+			retries++
+			rl.Take()
+
+			return deleteObject(ctx, o)
+		}, backoff.WithContext(backoff.WithMaxTries(bo, 10), ctx))
+		if err != nil && ctx.Err() != nil {
+			gt.release()
+			continue
+		}
+		if err != nil {
+			switch x := o.(type) {
+			case *model.SingleObject:
+				recordFailure(ctx, x, err, retries)
+			}
+			logrus.Errorf("%s object failed for %v.", t.Type, err)
+		} else if x, ok := o.(*model.SingleObject); ok {
+			clearFailure(ctx, x.Key)
+		}
+
+		gt.release()
+
+		err = model.DeleteObject(ctx, o)
+		if err != nil {
+			utils.CheckClosedDB(err)
+		}
+	}
+}
+
+// checkDeleteThreshold will check whether the objects to delete exceed the
+// task's delete threshold.
+func checkDeleteThreshold(ctx context.Context) (err error) {
+	n, err := model.CountSingleObject(ctx)
+	if err != nil {
+		return
+	}
+
+	logrus.Infof("%d of %d destination objects will be deleted.", n, t.DestinationCount)
+
+	if t.DeleteThreshold == 0 || n == 0 {
+		return
+	}
+	if n*100 > t.DestinationCount*int64(t.DeleteThreshold) {
+		logrus.Errorf("Deleting %d of %d destination objects exceeds delete threshold %d%%, abort. "+
+			"Raise delete_threshold in task file and run it again to continue.",
+			n, t.DestinationCount, t.DeleteThreshold)
+		return constants.ErrTaskDeleteThresholdExceeded
+	}
+	return
+}
+
+// syncTask will execute a sync task.
+func syncTask(ctx context.Context) (err error) {
+	if !CanSync() {
+		logrus.Infof("Source type %s and destination type %s not support sync.",
+			t.Src.Type, t.Dst.Type)
+		return
+	}
+	logrus.Debugf("Start sync task.")
+
+	err = ListDestination(ctx)
+	if err != nil {
+		logrus.Errorf("List destination failed for %v.", err)
+		return
+	}
+
+	err = copyTask(ctx)
+	if err != nil {
+		return
+	}
+
+	dctx := model.NewDestinationContext(ctx)
+
+	err = checkDeleteThreshold(dctx)
+	if err != nil {
+		return
+	}
+
+	return retryTask(dctx, func() error {
+		err := Purge(dctx)
+		if err != nil {
+			return err
+		}
+
+		if !isFinished(dctx) {
+			return constants.ErrTaskNotFinished
+		}
+
+		return nil
+	})
+}
+
+// syncKey will format the key which is used to match objects between src and
+// dst.
+func syncKey(p string) string {
+	return strings.TrimPrefix(p, "/")
+}
@@ -0,0 +1,93 @@
+package migrate
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/model"
+)
+
+// Verify will do verify job between src and dst.
+func Verify(ctx context.Context) (err error) {
+	oc = make(chan model.Object, contexts.Config.Concurrency*2)
+	jc = make(chan *model.DirectoryObject)
+
+	owg = &sync.WaitGroup{}
+	jwg = &sync.WaitGroup{}
+
+	// Wait for all object finished.
+	defer owg.Wait()
+	// Close channel for no more object.
+	defer close(oc)
+	// Close channel for no more job.
+	defer close(jc)
+	// Wait for all job finished.
+	defer jwg.Wait()
+
+	go listWorker(ctx)
+
+	for i := 0; i < contexts.Config.Concurrency; i++ {
+		owg.Add(1)
+		go migrateWorker(ctx)
+	}
+
+	err = List(ctx)
+	if err != nil {
+		logrus.Errorf("List failed for %v.", err)
+		return err
+	}
+
+	return
+}
+
+// verifyTask will execute a verify task.
+func verifyTask(ctx context.Context) (err error) {
+	logrus.Debugf("Start verify task.")
+
+	err = retryTask(ctx, func() error {
+		err := Verify(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !isFinished(ctx) {
+			return constants.ErrTaskNotFinished
+		}
+
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	return printReport(ctx)
+}
+
+// printReport will print the summary of verify report.
+func printReport(ctx context.Context) (err error) {
+	count := make(map[string]int)
+
+	p := ""
+	for {
+		r, err := model.NextReport(ctx, p)
+		if err != nil {
+			return err
+		}
+		if r == nil {
+			break
+		}
+
+		count[r.Type]++
+		p = r.Key
+	}
+
+	logrus.Infof("====Verify Missing: %d  Size Mismatch: %d  MD5 Mismatch: %d====",
+		count[constants.ReportTypeMissing],
+		count[constants.ReportTypeSizeMismatch],
+		count[constants.ReportTypeMD5Mismatch])
+	return
+}
@@ -5,4 +5,8 @@ type Endpoint struct {
 	Type    string                 `yaml:"type" msgpack:"t"`
 	Path    string                 `yaml:"path" msgpack:"p"`
 	Options map[string]interface{} `yaml:"options" msgpack:"o"`
+
+	// BandwidthLimit is the max bytes per second read from or written to
+	// this endpoint.
+	BandwidthLimit int64 `yaml:"bandwidth_limit" msgpack:"bw"`
 }
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/vmihailenco/msgpack"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/utils"
+)
+
+// Failure is the last failure of an object.
+type Failure struct {
+	Key   string `msgpack:"p" json:"key"`
+	Phase string `msgpack:"ph" json:"phase"`
+	Error string `msgpack:"e" json:"error"`
+
+	Retries   int   `msgpack:"r" json:"retries"`
+	Timestamp int64 `msgpack:"ts" json:"timestamp"`
+
+	// Object is the failed object which can be used to retry.
+	Object *SingleObject `msgpack:"o" json:"-"`
+}
+
+// CreateFailure will create or update a failure in db.
+func CreateFailure(ctx context.Context, f *Failure) (err error) {
+	t := utils.FromTaskContext(ctx)
+
+	content, err := msgpack.Marshal(f)
+	if err != nil {
+		logrus.Panicf("Msgpack marshal failed for %v.", err)
+	}
+
+	return contexts.DB.Put(constants.FormatFailureKey(t, f.Key), content, nil)
+}
+
+// DeleteFailure will delete a failure.
+func DeleteFailure(ctx context.Context, p string) (err error) {
+	t := utils.FromTaskContext(ctx)
+
+	return contexts.DB.Delete(constants.FormatFailureKey(t, p), nil)
+}
+
+// NextFailure will return the next failure after p.
+func NextFailure(ctx context.Context, p string) (f *Failure, err error) {
+	t := utils.FromTaskContext(ctx)
+
+	it := contexts.DB.NewIterator(
+		util.BytesPrefix(constants.FormatFailureKey(t, "")), nil)
+
+	for ok := it.Seek(constants.FormatFailureKey(t, p)); ok; ok = it.Next() {
+		k := it.Key()
+
+		// Check if the same key first, and go further.
+		if bytes.Compare(k, constants.FormatFailureKey(t, p)) == 0 {
+			continue
+		}
+		// If k doesn't has failure prefix, there are no failure any more.
+		if !bytes.HasPrefix(k, constants.FormatFailureKey(t, "")) {
+			break
+		}
+
+		f = &Failure{}
+		v := it.Value()
+		err = msgpack.Unmarshal(v, f)
+		if err != nil {
+			logrus.Panicf("Msgpack unmarshal failed for %v.", err)
+		}
+		break
+	}
+
+	it.Release()
+	if err == nil {
+		err = it.Error()
+	}
+	return
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"context"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/utils"
+)
+
+// GetKeyMapping will get the src key which has been mapped to dst key p.
+func GetKeyMapping(ctx context.Context, p string) (s string, ok bool, err error) {
+	t := utils.FromTaskContext(ctx)
+
+	content, err := contexts.DB.Get(constants.FormatKeyMappingKey(t, p), nil)
+	if err == leveldb.ErrNotFound {
+		return "", false, nil
+	}
+	if err != nil {
+		return
+	}
+	return string(content), true, nil
+}
+
+// CreateKeyMapping will record that src key s has been mapped to dst key p.
+func CreateKeyMapping(ctx context.Context, p, s string) (err error) {
+	t := utils.FromTaskContext(ctx)
+
+	return contexts.DB.Put(constants.FormatKeyMappingKey(t, p), []byte(s), nil)
+}
+
+// DeleteKeyMappings will delete all key mappings.
+func DeleteKeyMappings(ctx context.Context) (err error) {
+	t := utils.FromTaskContext(ctx)
+
+	it := contexts.DB.NewIterator(
+		util.BytesPrefix(constants.FormatKeyMappingKey(t, "")), nil)
+	for it.Next() {
+		err = contexts.DB.Delete(it.Key(), nil)
+		if err != nil {
+			break
+		}
+	}
+
+	it.Release()
+	if err == nil {
+		err = it.Error()
+	}
+	return
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/vmihailenco/msgpack"
 
@@ -102,6 +103,33 @@ func DeleteObject(ctx context.Context, o Object) (err error) {
 	}
 }
 
+// GetDirectoryObject will get directory object by it's key.
+func GetDirectoryObject(ctx context.Context, p string) (o *DirectoryObject, err error) {
+	t := utils.FromTaskContext(ctx)
+
+	content, err := contexts.DB.Get(constants.FormatDirectoryObjectKey(t, p), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return
+	}
+
+	o = &DirectoryObject{}
+	err = msgpack.Unmarshal(content, o)
+	if err != nil {
+		logrus.Panicf("Msgpack unmarshal failed for %v.", err)
+	}
+	return
+}
+
+// NewDestinationContext will create a ctx which stores objects listed from
+// destination next to the objects of current task.
+func NewDestinationContext(ctx context.Context) context.Context {
+	t := utils.FromTaskContext(ctx)
+	return context.WithValue(ctx, utils.ContextKeyTask, constants.FormatDestinationTaskName(t))
+}
+
 // HasDirectoryObject will check whether db has not finished directory object.
 func HasDirectoryObject(ctx context.Context) (b bool, err error) {
 	t := utils.FromTaskContext(ctx)
@@ -126,6 +154,36 @@ func HasParts(ctx context.Context, key string) (b bool, err error) {
 	return hasObject(ctx, constants.FormatPartialObjectKey(t, key, -1))
 }
 
+// CountDirectoryObject will count not finished directory objects.
+func CountDirectoryObject(ctx context.Context) (n int64, err error) {
+	t := utils.FromTaskContext(ctx)
+	return countObject(ctx, constants.FormatDirectoryObjectKey(t, ""))
+}
+
+// CountSingleObject will count not finished single objects.
+func CountSingleObject(ctx context.Context) (n int64, err error) {
+	t := utils.FromTaskContext(ctx)
+	return countObject(ctx, constants.FormatSingleObjectKey(t, ""))
+}
+
+// CountPartialObject will count not finished partial objects.
+func CountPartialObject(ctx context.Context) (n int64, err error) {
+	t := utils.FromTaskContext(ctx)
+	return countObject(ctx, constants.FormatPartialObjectKey(t, "", -1))
+}
+
+func countObject(ctx context.Context, v []byte) (n int64, err error) {
+	it := contexts.DB.NewIterator(
+		util.BytesPrefix(v), nil)
+	for it.Next() {
+		n++
+	}
+
+	it.Release()
+	err = it.Error()
+	return
+}
+
 func hasObject(ctx context.Context, v []byte) (b bool, err error) {
 	it := contexts.DB.NewIterator(
 		util.BytesPrefix(v), nil)
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/vmihailenco/msgpack"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/utils"
+)
+
+// Report is the verify result of an object which is not consistent between
+// src and dst.
+type Report struct {
+	Key  string `msgpack:"p" json:"key"`
+	Type string `msgpack:"t" json:"type"`
+
+	SrcSize int64  `msgpack:"ss" json:"src_size"`
+	DstSize int64  `msgpack:"ds" json:"dst_size"`
+	SrcMD5  string `msgpack:"sm" json:"src_md5,omitempty"`
+	DstMD5  string `msgpack:"dm" json:"dst_md5,omitempty"`
+}
+
+// CreateReport will create a report in db.
+func CreateReport(ctx context.Context, r *Report) (err error) {
+	t := utils.FromTaskContext(ctx)
+
+	content, err := msgpack.Marshal(r)
+	if err != nil {
+		logrus.Panicf("Msgpack marshal failed for %v.", err)
+	}
+
+	return contexts.DB.Put(constants.FormatReportKey(t, r.Key), content, nil)
+}
+
+// DeleteReport will delete a report.
+func DeleteReport(ctx context.Context, p string) (err error) {
+	t := utils.FromTaskContext(ctx)
+
+	return contexts.DB.Delete(constants.FormatReportKey(t, p), nil)
+}
+
+// NextReport will return the next report after p.
+func NextReport(ctx context.Context, p string) (r *Report, err error) {
+	t := utils.FromTaskContext(ctx)
+
+	it := contexts.DB.NewIterator(
+		util.BytesPrefix(constants.FormatReportKey(t, "")), nil)
+
+	for ok := it.Seek(constants.FormatReportKey(t, p)); ok; ok = it.Next() {
+		k := it.Key()
+
+		// Check if the same key first, and go further.
+		if bytes.Compare(k, constants.FormatReportKey(t, p)) == 0 {
+			continue
+		}
+		// If k doesn't has report prefix, there are no report any more.
+		if !bytes.HasPrefix(k, constants.FormatReportKey(t, "")) {
+			break
+		}
+
+		r = &Report{}
+		v := it.Value()
+		err = msgpack.Unmarshal(v, r)
+		if err != nil {
+			logrus.Panicf("Msgpack unmarshal failed for %v.", err)
+		}
+		break
+	}
+
+	it.Release()
+	if err == nil {
+		err = it.Error()
+	}
+	return
+}
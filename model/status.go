@@ -0,0 +1,82 @@
+package model
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// TaskStatus is the progress of a task.
+type TaskStatus struct {
+	Name   string `json:"name" yaml:"name"`
+	Type   string `json:"type" yaml:"type"`
+	Status string `json:"status" yaml:"status"`
+
+	PendingDirectoryObjects int64 `json:"pending_directory_objects" yaml:"pending_directory_objects"`
+	PendingSingleObjects    int64 `json:"pending_single_objects" yaml:"pending_single_objects"`
+	PendingPartialObjects   int64 `json:"pending_partial_objects" yaml:"pending_partial_objects"`
+
+	SuccessCount  int64    `json:"success_count" yaml:"success_count"`
+	SuccessSize   int64    `json:"success_size" yaml:"success_size"`
+	FailedCount   int      `json:"failed_count" yaml:"failed_count"`
+	FailedObjects []string `json:"failed_objects" yaml:"failed_objects"`
+
+	CreatedAt  string `json:"created_at" yaml:"created_at"`
+	StartedAt  string `json:"started_at" yaml:"started_at"`
+	FinishedAt string `json:"finished_at" yaml:"finished_at"`
+	// Throughput is the average success size per second.
+	Throughput float64 `json:"throughput" yaml:"throughput"`
+}
+
+// NewTaskStatus will collect the status of task t, pending objects are
+// counted from the task in ctx.
+func NewTaskStatus(ctx context.Context, t *Task) (s *TaskStatus, err error) {
+	s = &TaskStatus{
+		Name:          t.Name,
+		Type:          t.Type,
+		Status:        t.Status,
+		SuccessCount:  t.SuccessCount,
+		SuccessSize:   t.SuccessSize,
+		FailedCount:   len(t.FailedObjects),
+		FailedObjects: []string{},
+		CreatedAt:     formatTime(t.CreatedAt),
+		StartedAt:     formatTime(t.StartedAt),
+		FinishedAt:    formatTime(t.FinishedAt),
+	}
+	for k := range t.FailedObjects {
+		s.FailedObjects = append(s.FailedObjects, k)
+	}
+	sort.Strings(s.FailedObjects)
+
+	s.PendingDirectoryObjects, err = CountDirectoryObject(ctx)
+	if err != nil {
+		return
+	}
+	s.PendingSingleObjects, err = CountSingleObject(ctx)
+	if err != nil {
+		return
+	}
+	s.PendingPartialObjects, err = CountPartialObject(ctx)
+	if err != nil {
+		return
+	}
+
+	if t.StartedAt > 0 {
+		end := time.Now().Unix()
+		if t.FinishedAt > 0 {
+			end = t.FinishedAt
+		}
+		if d := end - t.StartedAt; d > 0 {
+			s.Throughput = float64(t.SuccessSize) / float64(d)
+		}
+	}
+	return
+}
+
+// formatTime will format unix timestamp, zero will be formatted as empty.
+func formatTime(v int64) string {
+	if v == 0 {
+		return ""
+	}
+	return time.Unix(v, 0).Format(time.RFC3339)
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"crypto/sha256"
 	"io/ioutil"
+	"path"
+	"regexp"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -31,21 +33,72 @@ type Task struct {
 	IgnoreBefore          string `yaml:"ignore_before" msgpack:"ib"` // Format: 2006-01-02 15:04:05
 	IgnoreBeforeTimestamp int64  `yaml:"-" msgpack:"ibt"`
 	RateLimit             int    `yaml:"rate_limit" msgpack:"rl"`
-	Workers               int    `yaml:"workers" msgpack:"wk"` // The number of workers for multipart uploads, default 100.
+	Workers               int    `yaml:"workers" msgpack:"wk"`          // The number of workers for multipart uploads, default 100.
+	DeleteThreshold       int    `yaml:"delete_threshold" msgpack:"dt"` // The max percent of destination objects that sync task can delete.
+	BandwidthLimit        int64  `yaml:"bandwidth_limit" msgpack:"bw"`  // The max bytes per second for all objects.
+
+	// Filters for objects.
+	Include                 []string `yaml:"include" msgpack:"inc"`
+	Exclude                 []string `yaml:"exclude" msgpack:"exc"`
+	IncludeRegex            []string `yaml:"include_regex" msgpack:"incr"`
+	ExcludeRegex            []string `yaml:"exclude_regex" msgpack:"excr"`
+	MinSize                 int64    `yaml:"min_size" msgpack:"mins"`
+	MaxSize                 int64    `yaml:"max_size" msgpack:"maxs"`
+	ModifiedAfter           string   `yaml:"modified_after" msgpack:"ma"`  // Format: 2006-01-02 15:04:05
+	ModifiedBefore          string   `yaml:"modified_before" msgpack:"mb"` // Format: 2006-01-02 15:04:05
+	ModifiedAfterTimestamp  int64    `yaml:"-" msgpack:"mat"`
+	ModifiedBeforeTimestamp int64    `yaml:"-" msgpack:"mbt"`
+
+	// Rules to transform src key to dst key.
+	KeyMapping []*KeyMappingRule `yaml:"key_mapping" msgpack:"km"`
+
+	// Rules to change bandwidth and concurrency in time windows.
+	Schedule []*ScheduleRule `yaml:"schedule" msgpack:"sch"`
 
 	// Statistical Information
 	SuccessCount  int64          `yaml:"-" msgpack:"sc"`
 	SuccessSize   int64          `yaml:"-" msgpack:"ss"`
-	FailedObjects map[string]int `yaml:"-" msgpack:"fo"`
+	FailedObjects map[string]int `yaml:"-" msgpack:"fo"` // Failed object key to retry count.
+
+	// Destination Information for sync task
+	DestinationListed bool  `yaml:"-" msgpack:"dl"`
+	DestinationCount  int64 `yaml:"-" msgpack:"dc"`
 
 	// Data that only stores in database.
-	Name   string `yaml:"-" msgpack:"n"`
-	Status string `yaml:"-" msgpack:"s"`
+	Name       string `yaml:"-" msgpack:"n"`
+	Status     string `yaml:"-" msgpack:"s"`
+	CreatedAt  int64  `yaml:"-" msgpack:"ca"`
+	StartedAt  int64  `yaml:"-" msgpack:"sa"` // The time when task run for the first time.
+	FinishedAt int64  `yaml:"-" msgpack:"fa"`
 
 	// Date that only keep in memory.
 	Handle func(ctx context.Context, o Object) (err error) `yaml:"-" msgpack:"-"`
 }
 
+// ScheduleRule will change task's bandwidth limit and concurrency while
+// current time is in the window.
+type ScheduleRule struct {
+	// Window is a cron-like spec: minute, hour, day of month, month and
+	// day of week.
+	Window string `yaml:"window" msgpack:"w"`
+
+	BandwidthLimit int64 `yaml:"bandwidth_limit" msgpack:"bw"`
+	Concurrency    int   `yaml:"concurrency" msgpack:"c"`
+	Pause          bool  `yaml:"pause" msgpack:"p"`
+}
+
+// KeyMappingRule is a rule to transform object key.
+type KeyMappingRule struct {
+	Type string `yaml:"type" msgpack:"t"`
+
+	// Value is the prefix for strip_prefix and add_prefix, and the time
+	// format for date_prefix.
+	Value string `yaml:"value" msgpack:"v"`
+	// Pattern and Replace are used for regex.
+	Pattern string `yaml:"pattern" msgpack:"p"`
+	Replace string `yaml:"replace" msgpack:"r"`
+}
+
 // LoadTask will try to load task from database and file.
 func LoadTask(name, taskPath string) (t *Task, err error) {
 	// Load from database first.
@@ -72,12 +125,25 @@ func LoadTask(name, taskPath string) (t *Task, err error) {
 	if task.RateLimit == 0 {
 		task.RateLimit = 1000
 	}
+	// Sync task should only copy new or changed objects.
+	if task.Type == constants.TaskTypeSync && task.IgnoreExisting == "" {
+		task.IgnoreExisting = constants.TaskIgnoreExistingLastModified
+	}
 
 	// If t is not nil and task path input, we should check the task content.
 	if t != nil {
 		if t.Sum256() != task.Sum256() {
 			return nil, constants.ErrTaskMismatch
 		}
+		// Delete threshold could be changed to resume a sync task which is
+		// aborted by it.
+		if t.DeleteThreshold != task.DeleteThreshold {
+			t.DeleteThreshold = task.DeleteThreshold
+			err = t.Save(nil)
+			if err != nil {
+				return
+			}
+		}
 		return t, nil
 	}
 
@@ -92,6 +158,22 @@ func LoadTask(name, taskPath string) (t *Task, err error) {
 		task.IgnoreBeforeTimestamp = ignoreBefore.Unix()
 	}
 
+	// Parse modified after and before
+	if task.ModifiedAfter != "" {
+		task.ModifiedAfterTimestamp, err = parseTime(task.ModifiedAfter)
+		if err != nil {
+			logrus.Errorf("%s is not a valid value for task modified after", task.ModifiedAfter)
+			return nil, constants.ErrTaskInvalid
+		}
+	}
+	if task.ModifiedBefore != "" {
+		task.ModifiedBeforeTimestamp, err = parseTime(task.ModifiedBefore)
+		if err != nil {
+			logrus.Errorf("%s is not a valid value for task modified before", task.ModifiedBefore)
+			return nil, constants.ErrTaskInvalid
+		}
+	}
+
 	// Init FailedObjects map
 	task.FailedObjects = make(map[string]int)
 
@@ -99,6 +181,7 @@ func LoadTask(name, taskPath string) (t *Task, err error) {
 	// created and save it.
 	task.Status = constants.TaskStatusCreated
 	task.Name = name
+	task.CreatedAt = time.Now().Unix()
 	err = task.Save(nil)
 	if err != nil {
 		return
@@ -140,12 +223,87 @@ func (t *Task) Check() error {
 		return constants.ErrTaskInvalid
 	}
 
+	if t.BandwidthLimit < 0 ||
+		(t.Src != nil && t.Src.BandwidthLimit < 0) ||
+		(t.Dst != nil && t.Dst.BandwidthLimit < 0) {
+		logrus.Errorf("Bandwidth limit should not be negative")
+		return constants.ErrTaskInvalid
+	}
+
+	if t.DeleteThreshold < 0 || t.DeleteThreshold > 100 {
+		logrus.Errorf("%d is not a valid value for task delete threshold", t.DeleteThreshold)
+		return constants.ErrTaskInvalid
+	}
+
+	for _, v := range append(t.Include, t.Exclude...) {
+		if _, err := path.Match(v, ""); err != nil {
+			logrus.Errorf("%s is not a valid glob pattern for task filter", v)
+			return constants.ErrTaskInvalid
+		}
+	}
+	for _, v := range append(t.IncludeRegex, t.ExcludeRegex...) {
+		if _, err := regexp.Compile(v); err != nil {
+			logrus.Errorf("%s is not a valid regex for task filter", v)
+			return constants.ErrTaskInvalid
+		}
+	}
+	// Sync task deletes dst objects not listed from src, which can't be
+	// matched correctly once keys are mapped.
+	if t.Type == constants.TaskTypeSync && len(t.KeyMapping) > 0 {
+		logrus.Errorf("Key mapping is not supported by sync task")
+		return constants.ErrTaskInvalid
+	}
+	for _, v := range t.KeyMapping {
+		switch v.Type {
+		case constants.KeyMappingStripPrefix, constants.KeyMappingAddPrefix, constants.KeyMappingDatePrefix:
+			if v.Value == "" {
+				logrus.Errorf("Value is required for key mapping %s", v.Type)
+				return constants.ErrTaskInvalid
+			}
+		case constants.KeyMappingLowercase:
+		case constants.KeyMappingRegex:
+			if _, err := regexp.Compile(v.Pattern); err != nil {
+				logrus.Errorf("%s is not a valid regex for key mapping", v.Pattern)
+				return constants.ErrTaskInvalid
+			}
+		default:
+			logrus.Errorf("%s is not a valid type for key mapping", v.Type)
+			return constants.ErrTaskInvalid
+		}
+	}
+	for _, v := range t.Schedule {
+		if _, err := utils.ParseCron(v.Window); err != nil {
+			logrus.Errorf("%s is not a valid window for task schedule", v.Window)
+			return constants.ErrTaskInvalid
+		}
+		if v.BandwidthLimit < 0 || v.Concurrency < 0 {
+			logrus.Errorf("Bandwidth limit and concurrency of task schedule should not be negative")
+			return constants.ErrTaskInvalid
+		}
+	}
+	if t.MinSize < 0 || t.MaxSize < 0 || (t.MaxSize > 0 && t.MinSize > t.MaxSize) {
+		logrus.Errorf("%d ~ %d is not a valid size range for task filter", t.MinSize, t.MaxSize)
+		return constants.ErrTaskInvalid
+	}
+
 	return nil
 }
 
-// Sum256 will calculate task's sha256.
+// parseTime will parse time in task's format to unix timestamp.
+func parseTime(s string) (ts int64, err error) {
+	x, err := time.Parse("2006-01-02 15:04:05", s)
+	if err != nil {
+		return
+	}
+	return x.Unix(), nil
+}
+
+// Sum256 will calculate task's sha256, delete threshold is not included.
 func (t *Task) Sum256() [sha256.Size]byte {
-	y, err := yaml.Marshal(t)
+	x := *t
+	x.DeleteThreshold = 0
+
+	y, err := yaml.Marshal(&x)
 	if err != nil {
 		logrus.Panicf("YAML marshal failed for %v.", err)
 	}
@@ -198,6 +356,27 @@ func DeleteTask(ctx context.Context) (err error) {
 
 // DeleteTaskByName will delete a task by it's name.
 func DeleteTaskByName(ctx context.Context, p string) (err error) {
+	ctx = utils.NewTaskContext(ctx, p)
+
+	err = deleteObjects(ctx, p)
+	if err != nil {
+		return
+	}
+	// Objects listed from destination by sync task should also be deleted.
+	err = deleteObjects(NewDestinationContext(ctx), p)
+	if err != nil {
+		return
+	}
+
+	err = contexts.DB.Delete(constants.FormatTaskKey(p), nil)
+	if err != nil {
+		return
+	}
+	return
+}
+
+// deleteObjects will delete all objects in ctx's task.
+func deleteObjects(ctx context.Context, p string) (err error) {
 	x := ""
 	for {
 		j, err := NextDirectoryObject(ctx, x)
@@ -261,7 +440,52 @@ func DeleteTaskByName(ctx context.Context, p string) (err error) {
 			p, po.Key, po.PartNumber)
 	}
 
-	err = contexts.DB.Delete(constants.FormatTaskKey(p), nil)
+	x = ""
+	for {
+		r, err := NextReport(ctx, x)
+		if err != nil {
+			return err
+		}
+		if r == nil {
+			break
+		}
+
+		err = DeleteReport(ctx, r.Key)
+		if err != nil {
+			return err
+		}
+
+		x = r.Key
+
+		logrus.Infof("Task %s, report %s has been deleted.", p, r.Key)
+	}
+
+	x = ""
+	for {
+		f, err := NextFailure(ctx, x)
+		if err != nil {
+			return err
+		}
+		if f == nil {
+			break
+		}
+
+		err = DeleteFailure(ctx, f.Key)
+		if err != nil {
+			return err
+		}
+
+		x = f.Key
+
+		logrus.Infof("Task %s, failure %s has been deleted.", p, f.Key)
+	}
+
+	err = DeleteKeyMappings(ctx)
+	if err != nil {
+		return
+	}
+
+	err = DeleteUploads(ctx)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/vmihailenco/msgpack"
+
+	"github.com/yunify/qscamel/constants"
+	"github.com/yunify/qscamel/contexts"
+	"github.com/yunify/qscamel/utils"
+)
+
+// Upload is a multipart upload whose metadata can only be applied while
+// completing, it's stored so that the upload can be completed after
+// qscamel restarted.
+type Upload struct {
+	ID  string `msgpack:"id"`
+	Key string `msgpack:"p"`
+
+	Metadata map[string]string `msgpack:"m"`
+}
+
+// CreateUpload will create or update an upload in db.
+func CreateUpload(ctx context.Context, u *Upload) (err error) {
+	t := utils.FromTaskContext(ctx)
+
+	content, err := msgpack.Marshal(u)
+	if err != nil {
+		logrus.Panicf("Msgpack marshal failed for %v.", err)
+	}
+
+	return contexts.DB.Put(constants.FormatUploadKey(t, u.ID), content, nil)
+}
+
+// GetUpload will get an upload by its id.
+func GetUpload(ctx context.Context, id string) (u *Upload, err error) {
+	t := utils.FromTaskContext(ctx)
+
+	content, err := contexts.DB.Get(constants.FormatUploadKey(t, id), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return
+	}
+
+	u = &Upload{}
+	err = msgpack.Unmarshal(content, u)
+	if err != nil {
+		logrus.Panicf("Msgpack unmarshal failed for %v.", err)
+	}
+	return
+}
+
+// DeleteUpload will delete an upload.
+func DeleteUpload(ctx context.Context, id string) (err error) {
+	t := utils.FromTaskContext(ctx)
+
+	return contexts.DB.Delete(constants.FormatUploadKey(t, id), nil)
+}
+
+// DeleteUploads will delete all uploads.
+func DeleteUploads(ctx context.Context) (err error) {
+	t := utils.FromTaskContext(ctx)
+
+	it := contexts.DB.NewIterator(
+		util.BytesPrefix(constants.FormatUploadKey(t, "")), nil)
+	for it.Next() {
+		err = contexts.DB.Delete(it.Key(), nil)
+		if err != nil {
+			break
+		}
+	}
+
+	it.Release()
+	if err == nil {
+		err = it.Error()
+	}
+	return
+}
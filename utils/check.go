@@ -6,6 +6,8 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/syndtr/goleveldb/leveldb"
+
+	"github.com/yunify/qscamel/constants"
 )
 
 // CheckError will execute the func, handle it's panic and error
@@ -36,3 +38,16 @@ func Recover() {
 		logrus.Errorf("Caught panic: %v, Trace: %s", x, debug.Stack())
 	}
 }
+
+// CheckPresignExpire will check the presign expire of endpoint name, and
+// return def if it's not set.
+func CheckPresignExpire(name string, expire, def int64) (int64, error) {
+	if expire < 0 {
+		logrus.Errorf("%s presign expire can't be negative.", name)
+		return 0, constants.ErrEndpointInvalid
+	}
+	if expire == 0 {
+		return def, nil
+	}
+	return expire, nil
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"context"
+	"time"
 )
 
 // ContextKey is the type for context key.
@@ -34,3 +35,22 @@ func NewTaskContext(ctx context.Context, t string) context.Context {
 	}
 	return context.WithValue(ctx, ContextKeyTask, t)
 }
+
+// detachedContext keeps the values of its parent but will never be canceled.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (deadline time.Time, ok bool) { return }
+func (detachedContext) Done() <-chan struct{}                   { return nil }
+func (detachedContext) Err() error                              { return nil }
+
+// NewDetachedContext will create a ctx with all values of ctx, which will not
+// be canceled with ctx. It's used for the work which should be finished even
+// if task has been interrupted.
+func NewDetachedContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return ctx
+	}
+	return detachedContext{ctx}
+}
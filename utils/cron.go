@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a cron-like time window: minute, hour, day of month, month and
+// day of week.
+type Cron struct {
+	fields [5]map[int]bool
+	// Whether day of month and day of week are restricted.
+	dom, dow bool
+}
+
+var cronBounds = [5][2]int{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 7},  // day of week, both 0 and 7 are Sunday
+}
+
+// ParseCron will parse a cron-like spec, for example "* 9-18 * * 1-5".
+// Every field supports "*", values, ranges, lists and steps.
+func ParseCron(spec string) (c *Cron, err error) {
+	fs := strings.Fields(spec)
+	if len(fs) != 5 {
+		return nil, fmt.Errorf("cron %q should have 5 fields", spec)
+	}
+
+	c = &Cron{}
+	for i, f := range fs {
+		c.fields[i], err = parseCronField(f, cronBounds[i][0], cronBounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("cron %q is invalid: %v", spec, err)
+		}
+	}
+	if c.fields[4][7] {
+		c.fields[4][0] = true
+	}
+	c.dom = fs[2] != "*"
+	c.dow = fs[4] != "*"
+	return
+}
+
+func parseCronField(f string, min, max int) (m map[int]bool, err error) {
+	m = make(map[int]bool)
+
+	for _, part := range strings.Split(f, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("step %q is invalid", part)
+			}
+			part = part[:i]
+		}
+
+		lo, hi := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			lo, err = strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, fmt.Errorf("value %q is invalid", part)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				hi, err = strconv.Atoi(bounds[1])
+				if err != nil {
+					return nil, fmt.Errorf("value %q is invalid", part)
+				}
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("value %q is out of range %d-%d", part, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			m[v] = true
+		}
+	}
+	return
+}
+
+// Match will check whether time tm is in the window.
+func (c *Cron) Match(tm time.Time) bool {
+	if !c.fields[0][tm.Minute()] || !c.fields[1][tm.Hour()] || !c.fields[3][int(tm.Month())] {
+		return false
+	}
+
+	dom := c.fields[2][tm.Day()]
+	dow := c.fields[4][int(tm.Weekday())]
+	// Same as cron, if both day of month and day of week are restricted,
+	// either of them matches is ok.
+	if c.dom && c.dow {
+		return dom || dow
+	}
+	return dom && dow
+}
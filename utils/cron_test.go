@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCron(t *testing.T) {
+	// 2021-03-01 is Monday.
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2021, 3, 1, hour, minute, 0, 0, time.Local)
+	}
+	sunday := time.Date(2021, 2, 28, 10, 0, 0, 0, time.Local)
+
+	cases := []struct {
+		spec     string
+		tm       time.Time
+		expected bool
+	}{
+		{"* * * * *", monday(0, 0), true},
+		{"* 9-17 * * 1-5", monday(9, 0), true},
+		{"* 9-17 * * 1-5", monday(18, 0), false},
+		{"* 9-17 * * 1-5", sunday, false},
+		{"*/15 * * * *", monday(1, 30), true},
+		{"*/15 * * * *", monday(1, 31), false},
+		{"0,30 * * * *", monday(1, 30), true},
+		{"* * * * 7", sunday, true},
+		{"* * 1 * 0", monday(1, 0), true},
+		{"* * 2 * 1", sunday, false},
+		{"* * * 3 *", sunday, false},
+	}
+	for _, v := range cases {
+		c, err := ParseCron(v.spec)
+		assert.NoError(t, err, v.spec)
+		assert.Equal(t, v.expected, c.Match(v.tm), v.spec)
+	}
+
+	for _, v := range []string{"", "* * * *", "60 * * * *", "* 5-1 * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := ParseCron(v)
+		assert.Error(t, err, v)
+	}
+}
@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/yunify/qscamel/constants"
+)
+
+// TrimQSMetaPrefix will trim QingStor user defined metadata prefix from k,
+// so that it can be set with the prefix of other services.
+func TrimQSMetaPrefix(k string) string {
+	if len(k) >= len(constants.QSMetaPrefix) &&
+		strings.EqualFold(k[:len(constants.QSMetaPrefix)], constants.QSMetaPrefix) {
+		return k[len(constants.QSMetaPrefix):]
+	}
+	return k
+}
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrimQSMetaPrefix(t *testing.T) {
+	cases := map[string]string{
+		"x-qs-meta-owner": "owner",
+		"X-QS-Meta-Owner": "Owner",
+		"owner":           "owner",
+		"x-qs-meta":       "x-qs-meta",
+		"x-qs-meta-":      "",
+	}
+	for in, out := range cases {
+		assert.Equal(t, out, TrimQSMetaPrefix(in), in)
+	}
+}